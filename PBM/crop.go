@@ -0,0 +1,108 @@
+package Netpbm
+
+import (
+	"fmt"
+
+	"github.com/dada416-lebg/Netpbm/internal/raster"
+)
+
+// Rect is a rectangle of pixels whose top-left corner is (X, Y).
+type Rect struct {
+	X, Y, Width, Height int
+}
+
+// Empty reports whether the rectangle contains no pixel.
+func (r Rect) Empty() bool {
+	return r.Width <= 0 || r.Height <= 0
+}
+
+// Intersect returns the intersection of both rectangles, with a zero size if they are disjoint.
+func (r Rect) Intersect(s Rect) Rect {
+	x0, y0 := max(r.X, s.X), max(r.Y, s.Y)
+	x1, y1 := min(r.X+r.Width, s.X+s.Width), min(r.Y+r.Height, s.Y+s.Height)
+	if x1 <= x0 || y1 <= y0 {
+		return Rect{x0, y0, 0, 0}
+	}
+	return Rect{x0, y0, x1 - x0, y1 - y0}
+}
+
+// EdgeMode tells which value the pixels outside the image take.
+type EdgeMode = raster.EdgeMode
+
+const (
+	// EdgeConstant uses a fixed value.
+	EdgeConstant = raster.EdgeConstant
+	// EdgeReplicate extends the nearest border pixel.
+	EdgeReplicate = raster.EdgeReplicate
+	// EdgeReflect mirrors the image across its border, border pixel included (dcba|abcd|dcba).
+	EdgeReflect = raster.EdgeReflect
+	// EdgeWrap tiles the image.
+	EdgeWrap = raster.EdgeWrap
+)
+
+// atEdge returns the pixel (x, y), possibly outside the image, according to the edge mode.
+func (pbm *PBM) atEdge(x, y int, mode EdgeMode, fill bool) bool {
+	sx, okX := raster.EdgeIndex(x, pbm.width, mode)
+	sy, okY := raster.EdgeIndex(y, pbm.height, mode)
+	if !okX || !okY {
+		return fill
+	}
+	return pbm.data[sy][sx]
+}
+
+// Crop keeps only the part of the image inside the rectangle r.
+func (pbm *PBM) Crop(r Rect) {
+	r = r.Intersect(Rect{0, 0, pbm.width, pbm.height})
+	if r.Empty() {
+		fmt.Println("The crop rectangle is outside the image.")
+		return
+	}
+	data := make([][]bool, r.Height)
+	for y := range data {
+		data[y] = append([]bool(nil), pbm.data[r.Y+y][r.X:r.X+r.Width]...)
+	}
+	pbm.data = data
+	pbm.width, pbm.height = r.Width, r.Height
+}
+
+// Pad adds borders of value fill around the image, with the given thickness on each side.
+func (pbm *PBM) Pad(top, right, bottom, left int, fill bool) {
+	pbm.Extend(top, right, bottom, left, EdgeConstant, fill)
+}
+
+// Extend enlarges the image by the given thickness on each side. The new pixels follow the edge mode;
+// fill is only used with EdgeConstant.
+func (pbm *PBM) Extend(top, right, bottom, left int, mode EdgeMode, fill bool) {
+	if top < 0 || right < 0 || bottom < 0 || left < 0 {
+		fmt.Println("Border thicknesses must be positive.")
+		return
+	}
+	width, height := pbm.width+left+right, pbm.height+top+bottom
+	data := make([][]bool, height)
+	for y := range data {
+		data[y] = make([]bool, width)
+		for x := range data[y] {
+			data[y][x] = pbm.atEdge(x-left, y-top, mode, fill)
+		}
+	}
+	pbm.data = data
+	pbm.width, pbm.height = width, height
+}
+
+// Trim removes the uniform borders of the image, whose value is the one of the top-left pixel,
+// and returns the rectangle that was kept. A uniform image is left unchanged.
+func (pbm *PBM) Trim() Rect {
+	if pbm.width == 0 || pbm.height == 0 {
+		return Rect{}
+	}
+	border := pbm.data[0][0]
+	x, y, w, h, ok := raster.ContentBounds(pbm.width, pbm.height, func(x, y int) bool {
+		return pbm.data[y][x] == border
+	})
+	if !ok {
+		return Rect{0, 0, pbm.width, pbm.height}
+	}
+	r := Rect{x, y, w, h}
+	pbm.Crop(r)
+	return r
+}
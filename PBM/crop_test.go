@@ -0,0 +1,44 @@
+package Netpbm
+
+import "testing"
+
+func TestPBMCropPadTrim(t *testing.T) {
+	pbm := newBlankPBM(12, 8)
+	pbm.DrawLine(Point{3, 2}, Point{8, 5}, ModeSet)
+	r := pbm.Trim()
+	if r != (Rect{3, 2, 6, 4}) || pbm.width != 6 || pbm.height != 4 || !pbm.data[0][0] || !pbm.data[3][5] {
+		t.Fatalf("Trim kept %v", r)
+	}
+
+	pbm.Pad(1, 1, 1, 1, true)
+	if pbm.width != 8 || pbm.height != 6 || !pbm.data[0][0] || pbm.data[1][2] {
+		t.Error("Pad wrong")
+	}
+
+	pbm.Crop(Rect{1, 1, 6, 4})
+	if r := pbm.Trim(); r != (Rect{0, 0, 6, 4}) {
+		t.Errorf("Trimming a diagonal should keep everything, got %v", r)
+	}
+}
+
+func TestPBMExtendWrap(t *testing.T) {
+	pbm := newBlankPBM(3, 1)
+	pbm.data[0][0] = true
+	pbm.Extend(0, 3, 0, 0, EdgeWrap, false)
+	want := []bool{true, false, false, true, false, false}
+	for x := range want {
+		if pbm.data[0][x] != want[x] {
+			t.Fatalf("Wrap wrong: %v", pbm.data[0])
+		}
+	}
+}
+
+func TestPBMExtendEmpty(t *testing.T) {
+	for _, mode := range []EdgeMode{EdgeConstant, EdgeReplicate, EdgeReflect, EdgeWrap} {
+		pbm := newBlankPBM(0, 0)
+		pbm.Extend(1, 2, 1, 2, mode, true)
+		if pbm.width != 4 || pbm.height != 2 || countSet(pbm) != 8 {
+			t.Errorf("Mode %d: extending an empty image should fill it, got %dx%d", mode, pbm.width, pbm.height)
+		}
+	}
+}
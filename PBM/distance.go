@@ -0,0 +1,219 @@
+package Netpbm
+
+import (
+	"fmt"
+	"math"
+
+	netpgm "github.com/dada416-lebg/Netpbm/PGM"
+)
+
+// DistanceMetric is the distance used by the distance transforms.
+type DistanceMetric int
+
+const (
+	// DistanceEuclidean is the exact Euclidean distance.
+	DistanceEuclidean DistanceMetric = iota
+	// DistanceChamfer approximates the Euclidean distance with steps of 1 between 4-neighbors
+	// and 4/3 between diagonal neighbors (the 3-4 chamfer).
+	DistanceChamfer
+	// DistanceManhattan is the city block distance, |dx| + |dy|.
+	DistanceManhattan
+)
+
+// DistanceMap holds a distance for each pixel of an image.
+type DistanceMap struct {
+	Width, Height int
+	// Values holds the distances in reading order.
+	Values []float64
+}
+
+// At returns the distance at (x, y).
+func (m *DistanceMap) At(x, y int) float64 {
+	return m.Values[y*m.Width+x]
+}
+
+// Max returns the largest finite distance of the map.
+func (m *DistanceMap) Max() float64 {
+	result := 0.0
+	for _, v := range m.Values {
+		if !math.IsInf(v, 1) {
+			result = math.Max(result, v)
+		}
+	}
+	return result
+}
+
+// DistanceTransform returns, for each pixel, its distance to the nearest unset pixel: 0 on unset pixels,
+// and the half-width of the stroke on the middle of set strokes. The outside of the image is ignored;
+// without any unset pixel, all distances are infinite. To measure the distance to the set pixels
+// instead, invert the image first.
+func (pbm *PBM) DistanceTransform(metric DistanceMetric) *DistanceMap {
+	w, h := pbm.width, pbm.height
+	m := &DistanceMap{Width: w, Height: h}
+	unset := func(x, y int) bool { return !pbm.data[y][x] }
+	switch metric {
+	case DistanceChamfer:
+		m.Values = chamferDistances(w, h, unset, 1, 4.0/3)
+	case DistanceManhattan:
+		m.Values = chamferDistances(w, h, unset, 1, math.Inf(1))
+	default:
+		m.Values = squaredDistances(w, h, unset)
+		for i, v := range m.Values {
+			m.Values[i] = math.Sqrt(v)
+		}
+	}
+	return m
+}
+
+// Offset grows the set regions by radius pixels, with round corners, or shrinks them when radius is negative.
+func (pbm *PBM) Offset(radius float64) {
+	grow := radius >= 0
+	if grow {
+		pbm.Invert()
+	}
+	m := pbm.DistanceTransform(DistanceEuclidean)
+	limit := math.Abs(radius)
+	for y := 0; y < pbm.height; y++ {
+		for x := 0; x < pbm.width; x++ {
+			if grow {
+				// The distances are measured to the original set pixels
+				pbm.data[y][x] = m.At(x, y) <= limit
+			} else {
+				pbm.data[y][x] = m.At(x, y) > limit
+			}
+		}
+	}
+}
+
+// chamferDistances returns the distances to the nearest feature pixel computed in two raster scans,
+// with a step of straight between 4-neighbors and diagonal between diagonal neighbors.
+func chamferDistances(width, height int, feature func(x, y int) bool, straight, diagonal float64) []float64 {
+	d := make([]float64, width*height)
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			if !feature(x, y) {
+				d[y*width+x] = math.Inf(1)
+			}
+		}
+	}
+	relax := func(x, y, dx, dy int, step float64) {
+		nx, ny := x+dx, y+dy
+		if nx >= 0 && nx < width && ny >= 0 && ny < height {
+			d[y*width+x] = math.Min(d[y*width+x], d[ny*width+nx]+step)
+		}
+	}
+	// Forward scan with the neighbors already visited, then backward scan with the others
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			relax(x, y, -1, 0, straight)
+			relax(x, y, 0, -1, straight)
+			relax(x, y, -1, -1, diagonal)
+			relax(x, y, 1, -1, diagonal)
+		}
+	}
+	for y := height - 1; y >= 0; y-- {
+		for x := width - 1; x >= 0; x-- {
+			relax(x, y, 1, 0, straight)
+			relax(x, y, 0, 1, straight)
+			relax(x, y, 1, 1, diagonal)
+			relax(x, y, -1, 1, diagonal)
+		}
+	}
+	return d
+}
+
+// squaredDistances returns, for each pixel of a width × height grid in reading order, the squared
+// Euclidean distance to the nearest feature pixel, with the algorithm of Felzenszwalb and Huttenlocher.
+// Pixels are at an infinite distance when there is no feature pixel.
+func squaredDistances(width, height int, feature func(x, y int) bool) []float64 {
+	d := make([]float64, width*height)
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			if !feature(x, y) {
+				d[y*width+x] = math.Inf(1)
+			}
+		}
+	}
+	line := make([]float64, max(width, height))
+	result := make([]float64, max(width, height))
+	for x := 0; x < width; x++ {
+		for y := 0; y < height; y++ {
+			line[y] = d[y*width+x]
+		}
+		lowerEnvelope(line[:height], result[:height])
+		for y := 0; y < height; y++ {
+			d[y*width+x] = result[y]
+		}
+	}
+	for y := 0; y < height; y++ {
+		lowerEnvelope(d[y*width:(y+1)*width], result[:width])
+		copy(d[y*width:], result[:width])
+	}
+	return d
+}
+
+// lowerEnvelope sets result[q] to the minimum over p of f[p] + (q - p)², in linear time.
+func lowerEnvelope(f, result []float64) {
+	n := len(f)
+	v := make([]int, n)       // Positions of the parabolas of the envelope
+	z := make([]float64, n+1) // Boundaries between them
+	k := -1
+	for q := 0; q < n; q++ {
+		if math.IsInf(f[q], 1) {
+			continue
+		}
+		for k >= 0 {
+			p := v[k]
+			s := ((f[q] + float64(q*q)) - (f[p] + float64(p*p))) / float64(2*(q-p))
+			if s > z[k] {
+				k++
+				v[k], z[k] = q, s
+				break
+			}
+			k--
+		}
+		if k < 0 {
+			k = 0
+			v[0], z[0] = q, math.Inf(-1)
+		}
+		z[k+1] = math.Inf(1)
+	}
+	if k < 0 {
+		for q := range result {
+			result[q] = math.Inf(1)
+		}
+		return
+	}
+	j := 0
+	for q := 0; q < n; q++ {
+		for z[j+1] < float64(q) {
+			j++
+		}
+		dq := float64(q - v[j])
+		result[q] = dq*dq + f[v[j]]
+	}
+}
+
+// Grayscale returns the distance map as a PGM image of Width x Height pixels with a maximum value of 255.
+// Each gray level is the distance multiplied by scale, rounded and limited to 255; infinite distances are 255.
+// When scale is 0, the largest finite distance becomes 255, and a map without finite distances is all 255.
+// A negative or NaN scale is rejected with an error.
+func (m *DistanceMap) Grayscale(scale float64) (*netpgm.PGM, error) {
+	if !(scale >= 0) {
+		return nil, fmt.Errorf("invalid scale %v: it must be positive or 0", scale)
+	}
+	if scale == 0 {
+		if largest := m.Max(); largest > 0 {
+			scale = 255 / largest
+		}
+	}
+	pgm := netpgm.NewPGM(m.Width, m.Height)
+	for i, v := range m.Values {
+		level := uint8(255)
+		if !math.IsInf(v, 1) {
+			level = uint8(math.Max(0, math.Min(math.Round(v*scale), 255)))
+		}
+		pgm.Set(i%m.Width, i/m.Width, level)
+	}
+	return pgm, nil
+}
@@ -0,0 +1,118 @@
+package Netpbm
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSquaredDistances(t *testing.T) {
+	d := squaredDistances(5, 4, func(x, y int) bool { return x == 1 && y == 1 })
+	for y := 0; y < 4; y++ {
+		for x := 0; x < 5; x++ {
+			want := float64((x-1)*(x-1) + (y-1)*(y-1))
+			if d[y*5+x] != want {
+				t.Fatalf("(%d, %d) = %v, want %v", x, y, d[y*5+x], want)
+			}
+		}
+	}
+	if d := squaredDistances(3, 3, func(x, y int) bool { return false }); !math.IsInf(d[4], 1) {
+		t.Fatal("Distance without feature should be infinite")
+	}
+}
+
+func TestPBMDistanceTransform(t *testing.T) {
+	// A single unset pixel in a set image
+	pbm := newBlankPBM(9, 7)
+	pbm.Invert()
+	pbm.data[3][4] = false
+
+	euclidean := pbm.DistanceTransform(DistanceEuclidean)
+	chamfer := pbm.DistanceTransform(DistanceChamfer)
+	manhattan := pbm.DistanceTransform(DistanceManhattan)
+	for y := 0; y < 7; y++ {
+		for x := 0; x < 9; x++ {
+			dx, dy := math.Abs(float64(x-4)), math.Abs(float64(y-3))
+			if got, want := euclidean.At(x, y), math.Hypot(dx, dy); math.Abs(got-want) > 1e-9 {
+				t.Fatalf("Euclidean (%d, %d) = %v, want %v", x, y, got, want)
+			}
+			if got, want := manhattan.At(x, y), dx+dy; got != want {
+				t.Fatalf("Manhattan (%d, %d) = %v, want %v", x, y, got, want)
+			}
+			want := math.Max(dx, dy) - math.Min(dx, dy) + math.Min(dx, dy)*4/3
+			if got := chamfer.At(x, y); math.Abs(got-want) > 1e-9 {
+				t.Fatalf("Chamfer (%d, %d) = %v, want %v", x, y, got, want)
+			}
+		}
+	}
+	if euclidean.Max() != 5 {
+		t.Errorf("Max = %v, want 5", euclidean.Max())
+	}
+
+	full := newBlankPBM(3, 3)
+	full.Invert()
+	if !math.IsInf(full.DistanceTransform(DistanceChamfer).At(1, 1), 1) {
+		t.Error("Distance without unset pixel should be infinite")
+	}
+}
+
+func TestPBMDistanceGrayscale(t *testing.T) {
+	pbm := newBlankPBM(5, 1)
+	pbm.data[0] = []bool{false, true, true, true, true}
+	m := pbm.DistanceTransform(DistanceEuclidean)
+	if pgm, err := m.Grayscale(0); err != nil || pgm.At(0, 0) != 0 || pgm.At(2, 0) != 128 || pgm.At(4, 0) != 255 {
+		t.Errorf("Automatic scale wrong: %v", err)
+	}
+	if pgm, err := m.Grayscale(100); err != nil || pgm.At(1, 0) != 100 || pgm.At(3, 0) != 255 {
+		t.Errorf("Fixed scale wrong: %v", err)
+	}
+	if _, err := m.Grayscale(-1); err == nil {
+		t.Error("Negative scale should be rejected")
+	}
+
+	// Without any unset pixel, every distance is infinite and shown as 255
+	full := newBlankPBM(3, 2)
+	for y := range full.data {
+		for x := range full.data[y] {
+			full.data[y][x] = true
+		}
+	}
+	pgm, err := full.DistanceTransform(DistanceEuclidean).Grayscale(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for y := 0; y < 2; y++ {
+		for x := 0; x < 3; x++ {
+			if v := pgm.At(x, y); v != 255 {
+				t.Errorf("All set: level at (%d, %d) is %d, wanted 255", x, y, v)
+			}
+		}
+	}
+
+	pgm, err = m.Grayscale(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	filename := filepath.Join(t.TempDir(), "distance.pgm")
+	if err := pgm.Save(filename); err != nil {
+		t.Fatal(err)
+	}
+	if data, err := os.ReadFile(filename); err != nil || string(data) != "P2\n5 1\n255\n0 64 128 191 255 \n" {
+		t.Errorf("Save wrong output: %q", data)
+	}
+}
+
+func TestPBMOffset(t *testing.T) {
+	pbm := newBlankPBM(21, 21)
+	pbm.data[10][10] = true
+	pbm.Offset(3)
+	// The disk of radius 3 has 29 pixels
+	if countSet(pbm) != 29 || !pbm.data[10][13] || pbm.data[13][13] {
+		t.Fatalf("Offset(3) wrong: %d pixels", countSet(pbm))
+	}
+	pbm.Offset(-2)
+	if countSet(pbm) != 5 || !pbm.data[10][11] || pbm.data[11][11] {
+		t.Fatalf("Offset(-2) wrong: %d pixels", countSet(pbm))
+	}
+}
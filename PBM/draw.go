@@ -0,0 +1,185 @@
+package Netpbm
+
+import (
+	"fmt"
+
+	"github.com/dada416-lebg/Netpbm/internal/font"
+	"github.com/dada416-lebg/Netpbm/internal/raster"
+)
+
+// DrawMode tells how a drawing primitive changes the pixels it covers.
+type DrawMode int
+
+const (
+	// ModeSet sets the covered pixels to true (black).
+	ModeSet DrawMode = iota
+	// ModeClear sets the covered pixels to false (white).
+	ModeClear
+	// ModeXor inverts the covered pixels.
+	ModeXor
+)
+
+// draw runs shape, which reports the pixels it covers through plot, and applies mode to them.
+// Pixels outside the image are ignored. With ModeXor each pixel is inverted once, even if
+// shape reports it several times, so that joins and overlapping spans do not cancel out.
+func (pbm *PBM) draw(mode DrawMode, shape func(plot func(x, y int))) {
+	inside := func(x, y int) bool {
+		return x >= 0 && x < pbm.width && y >= 0 && y < pbm.height
+	}
+	if mode != ModeXor {
+		value := mode == ModeSet
+		shape(func(x, y int) {
+			if inside(x, y) {
+				pbm.data[y][x] = value
+			}
+		})
+		return
+	}
+
+	covered := make([]bool, pbm.width*pbm.height)
+	shape(func(x, y int) {
+		if inside(x, y) {
+			covered[y*pbm.width+x] = true
+		}
+	})
+	for i, c := range covered {
+		if c {
+			y, x := i/pbm.width, i%pbm.width
+			pbm.data[y][x] = !pbm.data[y][x]
+		}
+	}
+}
+
+// spanPlotter adapts plot to the span callback used by raster.FillPolygons.
+func spanPlotter(plot func(x, y int)) func(y, x0, x1 int) {
+	return func(y, x0, x1 int) {
+		for x := x0; x < x1; x++ {
+			plot(x, y)
+		}
+	}
+}
+
+// bresenham calls plot for each pixel of the segment from p1 to p2.
+func bresenham(p1, p2 Point, plot func(x, y int)) {
+	raster.Line(p1.X, p1.Y, p2.X, p2.Y, plot)
+}
+
+// DrawLine draws a segment from p1 to p2.
+func (pbm *PBM) DrawLine(p1, p2 Point, mode DrawMode) {
+	pbm.draw(mode, func(plot func(x, y int)) {
+		bresenham(p1, p2, plot)
+	})
+}
+
+// rectangleOutline reports the border pixels of a rectangle.
+func rectangleOutline(p1 Point, width, height int, plot func(x, y int)) {
+	x2, y2 := p1.X+width-1, p1.Y+height-1
+	bresenham(p1, Point{x2, p1.Y}, plot)
+	bresenham(Point{x2, p1.Y}, Point{x2, y2}, plot)
+	bresenham(Point{x2, y2}, Point{p1.X, y2}, plot)
+	bresenham(Point{p1.X, y2}, p1, plot)
+}
+
+// DrawRectangle draws the border of the rectangle whose top-left corner is p1.
+func (pbm *PBM) DrawRectangle(p1 Point, width, height int, mode DrawMode) {
+	if width <= 0 || height <= 0 {
+		fmt.Println("Invalid rectangle size.")
+		return
+	}
+	pbm.draw(mode, func(plot func(x, y int)) {
+		rectangleOutline(p1, width, height, plot)
+	})
+}
+
+// DrawFilledRectangle fills the rectangle whose top-left corner is p1.
+func (pbm *PBM) DrawFilledRectangle(p1 Point, width, height int, mode DrawMode) {
+	if width <= 0 || height <= 0 {
+		fmt.Println("Invalid rectangle size.")
+		return
+	}
+	pbm.draw(mode, func(plot func(x, y int)) {
+		for y := max(p1.Y, 0); y < min(p1.Y+height, pbm.height); y++ {
+			for x := max(p1.X, 0); x < min(p1.X+width, pbm.width); x++ {
+				plot(x, y)
+			}
+		}
+	})
+}
+
+// DrawCircle draws the circle of the given center and radius.
+func (pbm *PBM) DrawCircle(center Point, radius int, mode DrawMode) {
+	if radius <= 0 {
+		fmt.Println("The circle radius must be positive.")
+		return
+	}
+	cx, cy := center.X, center.Y
+	pbm.draw(mode, func(plot func(x, y int)) {
+		raster.CircleOctant(radius, func(x, y int) {
+			// The eight symmetric points of the circle
+			plot(cx+x, cy+y)
+			plot(cx+y, cy+x)
+			plot(cx-y, cy+x)
+			plot(cx-x, cy+y)
+			plot(cx-x, cy-y)
+			plot(cx-y, cy-x)
+			plot(cx+y, cy-x)
+			plot(cx+x, cy-y)
+		})
+	})
+}
+
+// DrawFilledCircle fills the disk of the given center and radius.
+func (pbm *PBM) DrawFilledCircle(center Point, radius int, mode DrawMode) {
+	if radius <= 0 {
+		fmt.Println("The circle radius must be positive.")
+		return
+	}
+	cx, cy := center.X, center.Y
+	pbm.draw(mode, func(plot func(x, y int)) {
+		raster.CircleOctant(radius, func(x, y int) {
+			// Horizontal lines between the symmetric points
+			for _, row := range [4][2]int{{cy + y, x}, {cy - y, x}, {cy + x, y}, {cy - x, y}} {
+				bresenham(Point{cx - row[1], row[0]}, Point{cx + row[1], row[0]}, plot)
+			}
+		})
+	})
+}
+
+// DrawPolygon draws the closed outline of the polygon whose vertices are points.
+func (pbm *PBM) DrawPolygon(points []Point, mode DrawMode) {
+	if len(points) < 2 {
+		fmt.Println("A polygon needs at least 2 points.")
+		return
+	}
+	pbm.draw(mode, func(plot func(x, y int)) {
+		for i := range points {
+			bresenham(points[i], points[(i+1)%len(points)], plot)
+		}
+	})
+}
+
+// DrawFilledPolygon fills the polygon whose vertices are points, edges included.
+func (pbm *PBM) DrawFilledPolygon(points []Point, mode DrawMode) {
+	if len(points) < 3 {
+		fmt.Println("A polygon needs at least 3 points.")
+		return
+	}
+	// Vertices are pixel centers
+	polygon := make([]PointF, len(points))
+	for i, p := range points {
+		polygon[i] = PointF{X: float64(p.X) + 0.5, Y: float64(p.Y) + 0.5}
+	}
+	pbm.draw(mode, func(plot func(x, y int)) {
+		raster.FillPolygons([][]PointF{polygon}, FillNonZero, pbm.width, pbm.height, spanPlotter(plot))
+		for i := range points {
+			bresenham(points[i], points[(i+1)%len(points)], plot)
+		}
+	})
+}
+
+// DrawTextWithMode draws text with the given options, applying mode to the pixels of the glyphs.
+func (pbm *PBM) DrawTextWithMode(p Point, text string, opts TextOptions, mode DrawMode) {
+	pbm.draw(mode, func(plot func(x, y int)) {
+		font.Render(p.X, p.Y, text, opts, plot)
+	})
+}
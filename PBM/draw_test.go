@@ -0,0 +1,80 @@
+package Netpbm
+
+import "testing"
+
+func TestPBMDrawModes(t *testing.T) {
+	pbm := newBlankPBM(10, 10)
+	pbm.DrawFilledRectangle(Point{0, 0}, 10, 5, ModeSet)
+	if got := countSet(pbm); got != 50 {
+		t.Fatalf("Set: wanted 50 pixels got %d", got)
+	}
+	pbm.DrawFilledRectangle(Point{0, 0}, 5, 10, ModeClear)
+	if got := countSet(pbm); got != 25 {
+		t.Fatalf("Clear: wanted 25 pixels got %d", got)
+	}
+	// The top-right quarter turns off, the bottom-left quarter turns on
+	pbm.DrawFilledRectangle(Point{2, 2}, 6, 6, ModeXor)
+	if pbm.data[3][6] || !pbm.data[6][3] || !pbm.data[1][6] || pbm.data[6][1] {
+		t.Error("Xor wrong")
+	}
+}
+
+func TestPBMXorPolylineOnce(t *testing.T) {
+	// Vertices shared by two segments must be inverted only once
+	pbm := newBlankPBM(10, 10)
+	pbm.DrawPolygon([]Point{{1, 1}, {8, 1}, {8, 8}, {1, 8}}, ModeXor)
+	if got := countSet(pbm); got != 28 || !pbm.data[1][1] || !pbm.data[8][8] {
+		t.Errorf("Xor polygon: wanted 28 pixels got %d", got)
+	}
+
+	pbm = newBlankPBM(21, 21)
+	pbm.DrawFilledCircle(Point{10, 10}, 8, ModeXor)
+	pbm.DrawFilledCircle(Point{10, 10}, 8, ModeXor)
+	if got := countSet(pbm); got != 0 {
+		t.Errorf("Xoring a disk twice should restore the image, %d pixels left", got)
+	}
+}
+
+func TestPBMDrawShapes(t *testing.T) {
+	pbm := newBlankPBM(10, 10)
+	pbm.DrawLine(Point{0, 9}, Point{9, 0}, ModeSet)
+	pbm.DrawRectangle(Point{0, 0}, 10, 10, ModeSet)
+	if got := countSet(pbm); got != 36+8 {
+		t.Errorf("Line and rectangle: wanted 44 pixels got %d", got)
+	}
+
+	pbm = newBlankPBM(10, 10)
+	pbm.DrawFilledPolygon([]Point{{0, 0}, {9, 0}, {0, 9}}, ModeSet)
+	if got := countSet(pbm); got != 55 {
+		t.Errorf("Filled triangle: wanted 55 pixels got %d", got)
+	}
+
+	pbm = newBlankPBM(20, 20)
+	pbm.DrawCircle(Point{10, 10}, 6, ModeSet)
+	if !pbm.data[4][10] || !pbm.data[10][16] || pbm.data[10][10] {
+		t.Error("Circle wrong")
+	}
+}
+
+func TestPBMPathAndText(t *testing.T) {
+	pbm := newBlankPBM(20, 20)
+	path := NewPath()
+	path.MoveTo(0, 0)
+	path.LineTo(20, 0)
+	path.LineTo(0, 20)
+	path.Close()
+	pbm.FillPath(path, ModeSet)
+	pbm.StrokePathWidth(path, 4, ModeXor)
+	if pbm.data[18][0] || pbm.data[0][5] || !pbm.data[4][5] {
+		t.Error("Path drawing wrong")
+	}
+
+	text := newBlankPBM(20, 10)
+	text.DrawFilledRectangle(Point{0, 0}, 20, 10, ModeSet)
+	text.DrawTextWithMode(Point{0, 0}, "A", TextOptions{}, ModeXor)
+	plain := newBlankPBM(20, 10)
+	plain.DrawText(Point{0, 0}, "A", true)
+	if countSet(text) != 200-countSet(plain) {
+		t.Error("Xor text should invert the glyph pixels")
+	}
+}
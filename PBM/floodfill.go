@@ -0,0 +1,31 @@
+package Netpbm
+
+import "github.com/dada416-lebg/Netpbm/internal/raster"
+
+// Connectivity defines the neighborhood used to connect pixels together.
+type Connectivity int
+
+const (
+	// Connect4 connects a pixel to its horizontal and vertical neighbors.
+	Connect4 Connectivity = 4
+	// Connect8 also connects a pixel to its diagonal neighbors.
+	Connect8 Connectivity = 8
+)
+
+// FloodFill sets to value the connected region of pixels that have the same value as p.
+// A seed outside the image leaves it unchanged.
+func (pbm *PBM) FloodFill(p Point, value bool, connectivity Connectivity) {
+	if p.X < 0 || p.X >= pbm.width || p.Y < 0 || p.Y >= pbm.height {
+		return
+	}
+	seed := pbm.data[p.Y][p.X]
+	if seed == value {
+		// The region already has the right value
+		return
+	}
+	raster.ScanlineFill(pbm.width, pbm.height, p.X, p.Y, connectivity == Connect8, func(x, y int) bool {
+		return pbm.data[y][x] == seed
+	}, func(x, y int) {
+		pbm.data[y][x] = value
+	})
+}
@@ -0,0 +1,29 @@
+package Netpbm
+
+import "testing"
+
+func TestFloodFill(t *testing.T) {
+	pbm := newBlankPBM(6, 6)
+	for i := 0; i < 6; i++ {
+		pbm.data[i][5-i] = true
+	}
+	pbm.FloodFill(Point{X: 0, Y: 0}, true, Connect4)
+	if countSet(pbm) != 21 {
+		t.Errorf("4-connected fill wrong: %d", countSet(pbm))
+	}
+
+	// clearing the filled region also clears the diagonal, which is 4-connected to it
+	pbm.FloodFill(Point{X: 0, Y: 0}, false, Connect4)
+	if countSet(pbm) != 0 {
+		t.Errorf("Clearing the region wrong: %d", countSet(pbm))
+	}
+}
+
+func TestFloodFillSameValue(t *testing.T) {
+	pbm := newBlankPBM(3, 3)
+	pbm.FloodFill(Point{X: 1, Y: 1}, false, Connect8)
+	pbm.FloodFill(Point{X: 5, Y: 5}, true, Connect8)
+	if countSet(pbm) != 0 {
+		t.Error("Fill with the same value or out of bounds should do nothing")
+	}
+}
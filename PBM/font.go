@@ -0,0 +1,60 @@
+package Netpbm
+
+import (
+	"io"
+
+	"github.com/dada416-lebg/Netpbm/internal/font"
+)
+
+// Glyph is the bitmap of a single character.
+// As in the BDF format, XOffset and YOffset place the bottom-left corner of the bitmap
+// relative to the character origin, which lies on the baseline.
+type Glyph = font.Glyph
+
+// Font is a bitmap font. Fonts are shared by the three formats.
+type Font = font.Font
+
+// TextAlign defines the horizontal alignment of text lines.
+type TextAlign = font.TextAlign
+
+const (
+	AlignLeft   = font.AlignLeft
+	AlignCenter = font.AlignCenter
+	AlignRight  = font.AlignRight
+)
+
+// TextOptions holds the text rendering parameters.
+type TextOptions = font.TextOptions
+
+// MeasureText returns the width and height, in pixels, of the text rendered with the given options.
+func MeasureText(text string, opts TextOptions) (int, int) {
+	return font.MeasureText(text, opts)
+}
+
+// LoadBDF loads a font in BDF (Glyph Bitmap Distribution Format) from a file.
+func LoadBDF(filename string) (*Font, error) {
+	return font.LoadBDF(filename)
+}
+
+// ParseBDF reads a font in BDF format.
+func ParseBDF(r io.Reader) (*Font, error) {
+	return font.ParseBDF(r)
+}
+
+// DefaultFont returns the built-in bitmap font: 5x7 pixels with descenders, covering printable ASCII.
+func DefaultFont() *Font {
+	return font.DefaultFont()
+}
+
+// DrawText writes the text into the PBM image with the built-in font, from the top-left corner p.
+// Newline characters start a new line.
+func (pbm *PBM) DrawText(p Point, text string, value bool) {
+	pbm.DrawTextWithOptions(p, text, value, TextOptions{})
+}
+
+// DrawTextWithOptions writes the text into the PBM image with the given font, alignment and scale.
+func (pbm *PBM) DrawTextWithOptions(p Point, text string, value bool, opts TextOptions) {
+	font.Render(p.X, p.Y, text, opts, func(x, y int) {
+		pbm.Set(x, y, value)
+	})
+}
@@ -0,0 +1,57 @@
+package Netpbm
+
+import "testing"
+
+// newBlankPBM creates an empty in-memory image for the tests.
+func newBlankPBM(width, height int) *PBM {
+	data := make([][]bool, height)
+	for y := range data {
+		data[y] = make([]bool, width)
+	}
+	return &PBM{data, width, height, "P1"}
+}
+
+func countSet(pbm *PBM) int {
+	count := 0
+	for y := 0; y < pbm.height; y++ {
+		for x := 0; x < pbm.width; x++ {
+			if pbm.data[y][x] {
+				count++
+			}
+		}
+	}
+	return count
+}
+
+func TestDrawText(t *testing.T) {
+	pbm := newBlankPBM(20, 12)
+	pbm.DrawText(Point{X: 1, Y: 2}, "T", true)
+	if countSet(pbm) != 13 {
+		t.Errorf("Wrong number of pixels drawn: %d", countSet(pbm))
+	}
+	for y := 2; y <= 8; y++ {
+		if !pbm.data[y][3] {
+			t.Errorf("Pixel at (3, %d) not drawn", y)
+		}
+	}
+
+	// writing the same text in white clears it
+	pbm.DrawText(Point{X: 1, Y: 2}, "T", false)
+	if countSet(pbm) != 0 {
+		t.Error("Text not cleared")
+	}
+}
+
+func TestDrawTextAlignRight(t *testing.T) {
+	pbm := newBlankPBM(20, 12)
+	pbm.DrawTextWithOptions(Point{X: 20, Y: 0}, "ab\nb", true, TextOptions{Align: AlignRight})
+	// the last column of each character is empty since the advance is 6 pixels
+	for y := 0; y < 12; y++ {
+		if pbm.data[y][19] {
+			t.Errorf("Pixel at (19, %d) should be empty", y)
+		}
+	}
+	if !pbm.data[9][14] || !pbm.data[5][8] || !pbm.data[2][14] {
+		t.Error("Right-aligned text not placed correctly")
+	}
+}
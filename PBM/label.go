@@ -0,0 +1,216 @@
+package Netpbm
+
+import (
+	"math"
+
+	netppm "github.com/dada416-lebg/Netpbm/PPM"
+)
+
+// ComponentStats describes a connected component of set pixels.
+type ComponentStats struct {
+	// Label is the value of the pixels of the component in the label image, from 1.
+	Label int
+	// Area is the number of pixels of the component.
+	Area int
+	// Bounds is the smallest rectangle containing the component.
+	Bounds Rect
+	// Centroid is the mean position of the centers of its pixels.
+	Centroid PointF
+	// Perimeter is the number of pixel sides between the component and unset pixels or the outside of the image.
+	Perimeter int
+}
+
+// Components is the result of a connected component labeling.
+type Components struct {
+	Width, Height int
+	// Labels holds the label of each pixel in reading order, 0 for the unset pixels.
+	Labels []int
+	// Stats holds the statistics of each component, the component labeled l being at index l-1.
+	Stats []ComponentStats
+}
+
+// At returns the label of the pixel at (x, y), or 0 outside the image.
+func (c *Components) At(x, y int) int {
+	if x < 0 || x >= c.Width || y < 0 || y >= c.Height {
+		return 0
+	}
+	return c.Labels[y*c.Width+x]
+}
+
+// Label finds the connected components of set pixels with the given connectivity. Labels are
+// numbered from 1 in the order in which the components are first met, in reading order.
+func (pbm *PBM) Label(connectivity Connectivity) *Components {
+	w, h := pbm.width, pbm.height
+	labels := make([]int, w*h)
+
+	// First pass: provisional labels, with the equivalences kept in a union-find forest
+	parent := []int{0}
+	find := func(l int) int {
+		for parent[l] != l {
+			parent[l] = parent[parent[l]]
+			l = parent[l]
+		}
+		return l
+	}
+	union := func(a, b int) int {
+		a, b = find(a), find(b)
+		if a > b {
+			a, b = b, a
+		}
+		parent[b] = a
+		return a
+	}
+	previous := [][2]int{{-1, 0}, {0, -1}}
+	if connectivity == Connect8 {
+		previous = append(previous, [2]int{-1, -1}, [2]int{1, -1})
+	}
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			if !pbm.data[y][x] {
+				continue
+			}
+			label := 0
+			for _, d := range previous {
+				nx, ny := x+d[0], y+d[1]
+				if nx < 0 || nx >= w || ny < 0 {
+					continue
+				}
+				if l := labels[ny*w+nx]; l != 0 {
+					if label == 0 {
+						label = find(l)
+					} else {
+						label = union(label, l)
+					}
+				}
+			}
+			if label == 0 {
+				label = len(parent)
+				parent = append(parent, label)
+			}
+			labels[y*w+x] = label
+		}
+	}
+
+	// Second pass: final labels numbered in order, and statistics
+	final := make([]int, len(parent))
+	c := &Components{Width: w, Height: h, Labels: labels}
+	var sums [][2]float64
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			i := y*w + x
+			if labels[i] == 0 {
+				continue
+			}
+			root := find(labels[i])
+			if final[root] == 0 {
+				c.Stats = append(c.Stats, ComponentStats{Label: len(c.Stats) + 1, Bounds: Rect{x, y, 1, 1}})
+				sums = append(sums, [2]float64{})
+				final[root] = len(c.Stats)
+			}
+			l := final[root]
+			labels[i] = l
+			s := &c.Stats[l-1]
+			s.Area++
+			sums[l-1][0] += float64(x)
+			sums[l-1][1] += float64(y)
+			s.Bounds = s.Bounds.union(x, y)
+			for _, d := range [4][2]int{{1, 0}, {-1, 0}, {0, 1}, {0, -1}} {
+				if !pbm.At(x+d[0], y+d[1]) {
+					s.Perimeter++
+				}
+			}
+		}
+	}
+	for i := range c.Stats {
+		area := float64(c.Stats[i].Area)
+		c.Stats[i].Centroid = PointF{X: sums[i][0]/area + 0.5, Y: sums[i][1]/area + 0.5}
+	}
+	return c
+}
+
+// union returns the smallest rectangle containing r and the pixel (x, y).
+func (r Rect) union(x, y int) Rect {
+	x0, y0 := min(r.X, x), min(r.Y, y)
+	x1, y1 := max(r.X+r.Width, x+1), max(r.Y+r.Height, y+1)
+	return Rect{x0, y0, x1 - x0, y1 - y0}
+}
+
+// FilterByArea returns the components whose area is at least minArea, relabeled from 1 in the same order.
+func (c *Components) FilterByArea(minArea int) *Components {
+	relabel := make([]int, len(c.Stats)+1)
+	result := &Components{Width: c.Width, Height: c.Height, Labels: make([]int, len(c.Labels))}
+	for _, s := range c.Stats {
+		if s.Area >= minArea {
+			relabel[s.Label] = len(result.Stats) + 1
+			s.Label = len(result.Stats) + 1
+			result.Stats = append(result.Stats, s)
+		}
+	}
+	for i, l := range c.Labels {
+		result.Labels[i] = relabel[l]
+	}
+	return result
+}
+
+// Mask returns a PBM image whose set pixels are those of the components.
+func (c *Components) Mask() *PBM {
+	data := make([][]bool, c.Height)
+	for y := range data {
+		data[y] = make([]bool, c.Width)
+		for x := range data[y] {
+			data[y][x] = c.Labels[y*c.Width+x] != 0
+		}
+	}
+	return &PBM{data, c.Width, c.Height, "P1"}
+}
+
+// RemoveSmallComponents clears the connected components of set pixels whose area is less than minArea.
+func (pbm *PBM) RemoveSmallComponents(minArea int, connectivity Connectivity) {
+	c := pbm.Label(connectivity).FilterByArea(minArea)
+	for y := 0; y < pbm.height; y++ {
+		for x := 0; x < pbm.width; x++ {
+			pbm.data[y][x] = c.Labels[y*pbm.width+x] != 0
+		}
+	}
+}
+
+// Colorize returns the components as a color PPM image of Width x Height pixels.
+// Unset pixels are white and each component has its own color. Colors are spread around the hue circle
+// with the golden ratio, so neighboring labels look different.
+func (c *Components) Colorize() *netppm.PPM {
+	colors := make([]netppm.Pixel, len(c.Stats)+1)
+	colors[0] = netppm.Pixel{R: 255, G: 255, B: 255}
+	for l := 1; l < len(colors); l++ {
+		hue := math.Mod(float64(l)*0.618033988749895, 1)
+		colors[l] = hsvToRGB(hue, 0.75, 0.9)
+	}
+	ppm := netppm.NewPPM(c.Width, c.Height)
+	for i, l := range c.Labels {
+		ppm.Set(i%c.Width, i/c.Width, colors[l])
+	}
+	return ppm
+}
+
+// hsvToRGB converts a color given by its hue, saturation and value, all between 0 and 1.
+func hsvToRGB(h, s, v float64) netppm.Pixel {
+	h *= 6
+	i := math.Floor(h)
+	f := h - i
+	p, q, t := v*(1-s), v*(1-s*f), v*(1-s*(1-f))
+	var r, g, b float64
+	switch int(i) % 6 {
+	case 0:
+		r, g, b = v, t, p
+	case 1:
+		r, g, b = q, v, p
+	case 2:
+		r, g, b = p, v, t
+	case 3:
+		r, g, b = p, q, v
+	case 4:
+		r, g, b = t, p, v
+	default:
+		r, g, b = v, p, q
+	}
+	return netppm.Pixel{R: uint8(math.Round(r * 255)), G: uint8(math.Round(g * 255)), B: uint8(math.Round(b * 255))}
+}
@@ -0,0 +1,80 @@
+package Netpbm
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	netppm "github.com/dada416-lebg/Netpbm/PPM"
+)
+
+func TestPBMLabel(t *testing.T) {
+	pbm := newBlankPBM(12, 8)
+	pbm.DrawFilledRectangle(Point{1, 1}, 3, 2, ModeSet)
+	// A U shape: its two arms are only joined at the bottom, after both have been labeled
+	pbm.DrawFilledRectangle(Point{6, 1}, 1, 5, ModeSet)
+	pbm.DrawFilledRectangle(Point{9, 1}, 1, 5, ModeSet)
+	pbm.DrawFilledRectangle(Point{6, 5}, 4, 1, ModeSet)
+	// Two pixels touching by a corner
+	pbm.data[5][1], pbm.data[6][2] = true, true
+
+	c4 := pbm.Label(Connect4)
+	if len(c4.Stats) != 4 {
+		t.Fatalf("Connect4: wanted 4 components got %d", len(c4.Stats))
+	}
+	c8 := pbm.Label(Connect8)
+	if len(c8.Stats) != 3 {
+		t.Fatalf("Connect8: wanted 3 components got %d", len(c8.Stats))
+	}
+
+	rect, u, diagonal := c8.Stats[0], c8.Stats[1], c8.Stats[2]
+	if rect.Area != 6 || rect.Bounds != (Rect{1, 1, 3, 2}) || rect.Centroid != (PointF{X: 2.5, Y: 2}) || rect.Perimeter != 10 {
+		t.Errorf("Rectangle stats wrong: %+v", rect)
+	}
+	if u.Area != 12 || u.Bounds != (Rect{6, 1, 4, 5}) || c8.At(6, 1) != 2 || c8.At(9, 1) != 2 {
+		t.Errorf("U stats wrong: %+v", u)
+	}
+	if diagonal.Area != 2 || diagonal.Perimeter != 8 || c8.At(2, 6) != 3 {
+		t.Errorf("Diagonal stats wrong: %+v", diagonal)
+	}
+	if c8.At(0, 0) != 0 || c8.At(-1, 0) != 0 {
+		t.Error("Background should be labeled 0")
+	}
+
+	filtered := c8.FilterByArea(6)
+	if len(filtered.Stats) != 2 || filtered.At(1, 5) != 0 || filtered.At(6, 5) != 2 || filtered.Stats[1].Label != 2 {
+		t.Errorf("FilterByArea wrong: %+v", filtered.Stats)
+	}
+	if mask := filtered.Mask(); countSet(mask) != 18 {
+		t.Errorf("Mask wrong: %d pixels", countSet(mask))
+	}
+
+	pbm.RemoveSmallComponents(3, Connect4)
+	if countSet(pbm) != 18 {
+		t.Errorf("RemoveSmallComponents wrong: %d pixels", countSet(pbm))
+	}
+}
+
+func TestPBMColorizeLabels(t *testing.T) {
+	pbm := newBlankPBM(6, 3)
+	pbm.data[0][0], pbm.data[2][5], pbm.data[0][3] = true, true, true
+	components := pbm.Label(Connect8)
+	ppm := components.Colorize()
+	white := netppm.Pixel{R: 255, G: 255, B: 255}
+	if width, height := ppm.Size(); width != 6 || height != 3 || ppm.At(1, 1) != white {
+		t.Error("Background should be white")
+	}
+	a, b, c := ppm.At(0, 0), ppm.At(3, 0), ppm.At(5, 2)
+	if a == b || b == c || a == c || a == white {
+		t.Errorf("Components should have distinct colors: %v %v %v", a, b, c)
+	}
+
+	filename := filepath.Join(t.TempDir(), "labels.ppm")
+	if err := ppm.Save(filename); err != nil {
+		t.Fatal(err)
+	}
+	if data, err := os.ReadFile(filename); err != nil || !strings.HasPrefix(string(data), "P3\n6 3\n255\n57 108 230\n") {
+		t.Errorf("Save wrong header: %q", data)
+	}
+}
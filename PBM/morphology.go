@@ -0,0 +1,259 @@
+package Netpbm
+
+import "fmt"
+
+// StructuringElement is the shape used by the morphological operations, given as the offsets
+// of its pixels relative to its origin.
+type StructuringElement struct {
+	offsets []Point
+}
+
+// SquareElement returns a size × size square centered on its origin.
+func SquareElement(size int) StructuringElement {
+	var se StructuringElement
+	for y := 0; y < size; y++ {
+		for x := 0; x < size; x++ {
+			se.offsets = append(se.offsets, Point{x - size/2, y - size/2})
+		}
+	}
+	return se
+}
+
+// DiskElement returns the disk of the given radius centered on its origin.
+func DiskElement(radius int) StructuringElement {
+	var se StructuringElement
+	for y := -radius; y <= radius; y++ {
+		for x := -radius; x <= radius; x++ {
+			if x*x+y*y <= radius*radius {
+				se.offsets = append(se.offsets, Point{x, y})
+			}
+		}
+	}
+	return se
+}
+
+// CrossElement returns a cross whose four arms extend radius pixels from its origin.
+func CrossElement(radius int) StructuringElement {
+	se := StructuringElement{offsets: []Point{{0, 0}}}
+	for i := 1; i <= radius; i++ {
+		se.offsets = append(se.offsets, Point{i, 0}, Point{-i, 0}, Point{0, i}, Point{0, -i})
+	}
+	return se
+}
+
+// ElementFromPBM returns the element made of the set pixels of pattern, with origin at the given
+// pixel of the pattern.
+func ElementFromPBM(pattern *PBM, origin Point) StructuringElement {
+	var se StructuringElement
+	for y := 0; y < pattern.height; y++ {
+		for x := 0; x < pattern.width; x++ {
+			if pattern.data[y][x] {
+				se.offsets = append(se.offsets, Point{x - origin.X, y - origin.Y})
+			}
+		}
+	}
+	return se
+}
+
+// bitmap is a binary image packed 64 pixels per word, pixel x of a row being bit x%64 of word x/64.
+// The bits past the width are always zero.
+type bitmap struct {
+	width, height, words int
+	bits                 []uint64
+}
+
+func newBitmap(width, height int) *bitmap {
+	words := (width + 63) / 64
+	return &bitmap{width, height, words, make([]uint64, words*height)}
+}
+
+// pack returns the pixels of the image as a bitmap.
+func (pbm *PBM) pack() *bitmap {
+	b := newBitmap(pbm.width, pbm.height)
+	for y := 0; y < pbm.height; y++ {
+		row := b.row(y)
+		for x := 0; x < pbm.width; x++ {
+			if pbm.data[y][x] {
+				row[x/64] |= 1 << (x % 64)
+			}
+		}
+	}
+	return b
+}
+
+// unpack replaces the pixels of the image with those of the bitmap.
+func (pbm *PBM) unpack(b *bitmap) {
+	for y := 0; y < pbm.height; y++ {
+		row := b.row(y)
+		for x := 0; x < pbm.width; x++ {
+			pbm.data[y][x] = row[x/64]&(1<<(x%64)) != 0
+		}
+	}
+}
+
+func (b *bitmap) row(y int) []uint64 {
+	return b.bits[y*b.words : (y+1)*b.words]
+}
+
+// lastMask returns the mask of the valid bits of the last word of a row.
+func (b *bitmap) lastMask() uint64 {
+	if b.width%64 == 0 {
+		return ^uint64(0)
+	}
+	return 1<<(b.width%64) - 1
+}
+
+// shiftRow sets bit x of dst to bit x+shift of src, or to fill when x+shift is outside the row.
+func (b *bitmap) shiftRow(dst, src []uint64, shift int, fill bool) {
+	var fillWord uint64
+	if fill {
+		fillWord = ^uint64(0)
+	}
+	mask := b.lastMask()
+	word := func(j int) uint64 {
+		switch {
+		case j < 0 || j >= b.words:
+			return fillWord
+		case j == b.words-1:
+			return src[j] | fillWord&^mask
+		}
+		return src[j]
+	}
+	// Arithmetic shifts round toward minus infinity, also for negative shifts
+	wordShift, bitShift := shift>>6, uint(shift&63)
+	for i := range dst {
+		lo := word(i + wordShift)
+		if bitShift == 0 {
+			dst[i] = lo
+		} else {
+			dst[i] = lo>>bitShift | word(i+wordShift+1)<<(64-bitShift)
+		}
+	}
+	dst[b.words-1] &= mask
+}
+
+// erode returns the erosion of b by se. Pixels outside the image do not constrain the result.
+func (b *bitmap) erode(se StructuringElement) *bitmap {
+	result := newBitmap(b.width, b.height)
+	for i := range result.bits {
+		result.bits[i] = ^uint64(0)
+	}
+	shifted := make([]uint64, b.words)
+	for y := 0; y < b.height; y++ {
+		dst := result.row(y)
+		for _, o := range se.offsets {
+			if y+o.Y < 0 || y+o.Y >= b.height {
+				continue
+			}
+			b.shiftRow(shifted, b.row(y+o.Y), o.X, true)
+			for i := range dst {
+				dst[i] &= shifted[i]
+			}
+		}
+		dst[b.words-1] &= b.lastMask()
+	}
+	return result
+}
+
+// dilate returns the dilation of b by se. Pixels outside the image are background.
+func (b *bitmap) dilate(se StructuringElement) *bitmap {
+	result := newBitmap(b.width, b.height)
+	shifted := make([]uint64, b.words)
+	for y := 0; y < b.height; y++ {
+		dst := result.row(y)
+		for _, o := range se.offsets {
+			if y-o.Y < 0 || y-o.Y >= b.height {
+				continue
+			}
+			b.shiftRow(shifted, b.row(y-o.Y), -o.X, false)
+			for i := range dst {
+				dst[i] |= shifted[i]
+			}
+		}
+	}
+	return result
+}
+
+// invert returns the complement of b.
+func (b *bitmap) invert() *bitmap {
+	result := newBitmap(b.width, b.height)
+	for i, w := range b.bits {
+		result.bits[i] = ^w
+	}
+	for y := 0; y < b.height; y++ {
+		result.row(y)[b.words-1] &= b.lastMask()
+	}
+	return result
+}
+
+// morphology replaces the pixels of the image with the result of op applied to its packed bits.
+func (pbm *PBM) morphology(op func(b *bitmap) *bitmap) {
+	if pbm.width == 0 || pbm.height == 0 {
+		return
+	}
+	pbm.unpack(op(pbm.pack()))
+}
+
+// Erode keeps the set pixels where se, placed on them by its origin, fits entirely in the set pixels.
+// Pixels outside the image do not erode the borders.
+func (pbm *PBM) Erode(se StructuringElement) {
+	pbm.morphology(func(b *bitmap) *bitmap { return b.erode(se) })
+}
+
+// Dilate sets every pixel covered by se placed by its origin on a set pixel.
+func (pbm *PBM) Dilate(se StructuringElement) {
+	pbm.morphology(func(b *bitmap) *bitmap { return b.dilate(se) })
+}
+
+// Open erodes then dilates the image, removing the details smaller than se.
+func (pbm *PBM) Open(se StructuringElement) {
+	pbm.morphology(func(b *bitmap) *bitmap { return b.erode(se).dilate(se) })
+}
+
+// Close dilates then erodes the image, filling the holes and gaps smaller than se.
+func (pbm *PBM) Close(se StructuringElement) {
+	pbm.morphology(func(b *bitmap) *bitmap { return b.dilate(se).erode(se) })
+}
+
+// TopHat keeps the set pixels removed by an opening with se: the details smaller than se.
+func (pbm *PBM) TopHat(se StructuringElement) {
+	pbm.morphology(func(b *bitmap) *bitmap {
+		opened := b.erode(se).dilate(se)
+		for i := range opened.bits {
+			opened.bits[i] = b.bits[i] &^ opened.bits[i]
+		}
+		return opened
+	})
+}
+
+// BlackTopHat keeps the pixels added by a closing with se: the holes and gaps smaller than se.
+func (pbm *PBM) BlackTopHat(se StructuringElement) {
+	pbm.morphology(func(b *bitmap) *bitmap {
+		closed := b.dilate(se).erode(se)
+		for i := range closed.bits {
+			closed.bits[i] &^= b.bits[i]
+		}
+		return closed
+	})
+}
+
+// HitOrMiss keeps the pixels where hit fits in the set pixels and miss fits in the unset pixels.
+// The two elements must not share an offset, otherwise no pixel can match.
+func (pbm *PBM) HitOrMiss(hit, miss StructuringElement) {
+	for _, h := range hit.offsets {
+		for _, m := range miss.offsets {
+			if h == m {
+				fmt.Println("The hit and miss elements overlap.")
+				return
+			}
+		}
+	}
+	pbm.morphology(func(b *bitmap) *bitmap {
+		result := b.erode(hit)
+		background := b.invert().erode(miss)
+		for i := range result.bits {
+			result.bits[i] &= background.bits[i]
+		}
+		return result
+	})
+}
@@ -0,0 +1,122 @@
+package Netpbm
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// bruteMorphology computes an erosion or a dilation pixel by pixel.
+func bruteMorphology(pbm *PBM, se StructuringElement, erode bool) [][]bool {
+	result := make([][]bool, pbm.height)
+	for y := range result {
+		result[y] = make([]bool, pbm.width)
+		for x := range result[y] {
+			value := erode
+			for _, o := range se.offsets {
+				if erode {
+					sx, sy := x+o.X, y+o.Y
+					if sx >= 0 && sx < pbm.width && sy >= 0 && sy < pbm.height && !pbm.data[sy][sx] {
+						value = false
+					}
+				} else if pbm.At(x-o.X, y-o.Y) {
+					value = true
+				}
+			}
+			result[y][x] = value
+		}
+	}
+	return result
+}
+
+func randomPBM(width, height int, density float64, seed int64) *PBM {
+	rng := rand.New(rand.NewSource(seed))
+	pbm := newBlankPBM(width, height)
+	for y := range pbm.data {
+		for x := range pbm.data[y] {
+			pbm.data[y][x] = rng.Float64() < density
+		}
+	}
+	return pbm
+}
+
+func TestPBMErodeDilateMatchBruteForce(t *testing.T) {
+	asymmetric := ElementFromPBM(&PBM{data: [][]bool{{true, true, false}, {false, true, true}}, width: 3, height: 2}, Point{0, 1})
+	elements := []StructuringElement{SquareElement(3), SquareElement(4), DiskElement(3), CrossElement(2), asymmetric}
+	for _, width := range []int{5, 64, 130} {
+		for i, se := range elements {
+			for _, erode := range []bool{true, false} {
+				pbm := randomPBM(width, 9, 0.6, int64(width+i))
+				want := bruteMorphology(pbm, se, erode)
+				if erode {
+					pbm.Erode(se)
+				} else {
+					pbm.Dilate(se)
+				}
+				for y := range want {
+					for x := range want[y] {
+						if pbm.data[y][x] != want[y][x] {
+							t.Fatalf("width %d, element %d, erode %v: (%d, %d) differs", width, i, erode, x, y)
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestPBMOpenClose(t *testing.T) {
+	pbm := newBlankPBM(20, 20)
+	pbm.DrawFilledRectangle(Point{3, 3}, 8, 8, ModeSet)
+	pbm.data[15][15] = true
+	pbm.data[6][6] = false
+
+	opened := newBlankPBM(20, 20)
+	copyPBM(opened, pbm)
+	opened.Open(SquareElement(3))
+	if opened.data[15][15] || !opened.data[3][3] || !opened.data[10][10] {
+		t.Error("Open should remove the isolated pixel and keep the square")
+	}
+
+	closed := newBlankPBM(20, 20)
+	copyPBM(closed, pbm)
+	closed.Close(SquareElement(3))
+	if !closed.data[6][6] || !closed.data[15][15] || closed.data[2][2] {
+		t.Error("Close should fill the hole and keep the rest")
+	}
+
+	topHat := newBlankPBM(20, 20)
+	copyPBM(topHat, pbm)
+	topHat.TopHat(SquareElement(3))
+	if countSet(topHat) != 1 || !topHat.data[15][15] {
+		t.Errorf("TopHat should keep only the isolated pixel, got %d pixels", countSet(topHat))
+	}
+	pbm.BlackTopHat(SquareElement(3))
+	if countSet(pbm) != 1 || !pbm.data[6][6] {
+		t.Errorf("BlackTopHat should keep only the hole, got %d pixels", countSet(pbm))
+	}
+}
+
+func TestPBMHitOrMiss(t *testing.T) {
+	// Isolated pixels: the center is set and its eight neighbors are not
+	hit := SquareElement(1)
+	var miss StructuringElement
+	for _, o := range SquareElement(3).offsets {
+		if o != (Point{0, 0}) {
+			miss.offsets = append(miss.offsets, o)
+		}
+	}
+	pbm := newBlankPBM(70, 10)
+	pbm.data[2][2] = true
+	pbm.data[5][66] = true
+	pbm.data[7][30], pbm.data[7][31] = true, true
+	pbm.HitOrMiss(hit, miss)
+	if countSet(pbm) != 2 || !pbm.data[2][2] || !pbm.data[5][66] {
+		t.Errorf("HitOrMiss should find the two isolated pixels, got %d", countSet(pbm))
+	}
+}
+
+func copyPBM(dst, src *PBM) {
+	for y := range src.data {
+		copy(dst.data[y], src.data[y])
+	}
+}
@@ -0,0 +1,65 @@
+package Netpbm
+
+import "github.com/dada416-lebg/Netpbm/internal/raster"
+
+// PointF is a point with floating-point coordinates.
+type PointF = raster.PointF
+
+// FillRule is the rule used to decide whether a point is inside a path.
+type FillRule = raster.FillRule
+
+const (
+	// FillNonZero fills the areas whose winding number is not zero.
+	FillNonZero = raster.FillNonZero
+	// FillEvenOdd fills the areas crossed an odd number of times.
+	FillEvenOdd = raster.FillEvenOdd
+)
+
+// Path is a vector path made of one or more subpaths. Building and flattening curves
+// is shared by the three formats.
+type Path struct {
+	raster.Path
+}
+
+// NewPath creates an empty path.
+func NewPath() *Path {
+	return &Path{*raster.NewPath()}
+}
+
+// StrokePath strokes the path with one-pixel lines.
+func (pbm *PBM) StrokePath(path *Path, mode DrawMode) {
+	pbm.draw(mode, func(plot func(x, y int)) {
+		for _, polyline := range path.Polylines() {
+			if len(polyline) == 1 {
+				plot(raster.Round(polyline[0].X), raster.Round(polyline[0].Y))
+			}
+			for i := 0; i+1 < len(polyline); i++ {
+				bresenham(roundPoint(polyline[i]), roundPoint(polyline[i+1]), plot)
+			}
+		}
+	})
+}
+
+// StrokePathWidth strokes the path with a line of the given width and round joins.
+func (pbm *PBM) StrokePathWidth(path *Path, width float64, mode DrawMode) {
+	if width <= 1 {
+		pbm.StrokePath(path, mode)
+		return
+	}
+	polygons := raster.StrokePolygons(&path.Path, width, path.EffectiveTolerance())
+	pbm.draw(mode, func(plot func(x, y int)) {
+		raster.FillPolygons(polygons, FillNonZero, pbm.width, pbm.height, spanPlotter(plot))
+	})
+}
+
+// FillPath fills the inside of the path with its fill rule. Open subpaths are implicitly closed.
+func (pbm *PBM) FillPath(path *Path, mode DrawMode) {
+	pbm.draw(mode, func(plot func(x, y int)) {
+		raster.FillPolygons(path.Polylines(), path.FillRule, pbm.width, pbm.height, spanPlotter(plot))
+	})
+}
+
+// roundPoint rounds a point to the nearest pixel.
+func roundPoint(p PointF) Point {
+	return Point{raster.Round(p.X), raster.Round(p.Y)}
+}
@@ -14,6 +14,11 @@ type PBM struct {
 	magicNumber   string
 }
 
+// Point is the position of a pixel.
+type Point struct {
+	X, Y int
+}
+
 func ReadPBM(filename string) (*PBM, error) {
 	file, err := os.Open(filename)
 	if err != nil {
@@ -0,0 +1,57 @@
+package Netpbm
+
+import "fmt"
+
+// Resize scales the image to width × height pixels while preserving thin features.
+// When shrinking, a destination pixel is set if any source pixel it covers is set, so that
+// one-pixel lines and isolated dots do not disappear. When enlarging, each destination pixel
+// takes the value of the source pixel under its center.
+func (pbm *PBM) Resize(width, height int) {
+	if width <= 0 || height <= 0 {
+		fmt.Println("Invalid size for the resized image.")
+		return
+	}
+	columns := resizeRanges(pbm.width, width)
+	rows := resizeRanges(pbm.height, height)
+
+	data := make([][]bool, height)
+	for y, r := range rows {
+		data[y] = make([]bool, width)
+		for x, c := range columns {
+			data[y][x] = pbm.anySet(c[0], r[0], c[1], r[1])
+		}
+	}
+
+	pbm.data = data
+	pbm.width = width
+	pbm.height = height
+}
+
+// resizeRanges returns, for each destination index, the range [start, end) of source indices it covers.
+func resizeRanges(srcSize, dstSize int) [][2]int {
+	ranges := make([][2]int, dstSize)
+	for i := range ranges {
+		if dstSize >= srcSize {
+			// Enlarging: the source pixel under the center
+			center := (2*i + 1) * srcSize / (2 * dstSize)
+			ranges[i] = [2]int{center, center + 1}
+			continue
+		}
+		start := i * srcSize / dstSize
+		end := ((i+1)*srcSize + dstSize - 1) / dstSize
+		ranges[i] = [2]int{start, end}
+	}
+	return ranges
+}
+
+// anySet reports whether a pixel of the rectangle [x0, x1) × [y0, y1) is set.
+func (pbm *PBM) anySet(x0, y0, x1, y1 int) bool {
+	for y := y0; y < y1; y++ {
+		for x := x0; x < x1; x++ {
+			if pbm.data[y][x] {
+				return true
+			}
+		}
+	}
+	return false
+}
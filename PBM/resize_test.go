@@ -0,0 +1,30 @@
+package Netpbm
+
+import "testing"
+
+func TestPBMResizeKeepsThinLines(t *testing.T) {
+	pbm := newBlankPBM(40, 40)
+	pbm.DrawLine(Point{0, 17}, Point{39, 17}, ModeSet)
+	pbm.Set(30, 33, true)
+	pbm.Resize(10, 10)
+	for x := 0; x < 10; x++ {
+		if !pbm.data[4][x] {
+			t.Errorf("Thin line lost at column %d", x)
+		}
+	}
+	if !pbm.data[8][7] {
+		t.Error("Isolated dot lost")
+	}
+	if got := countSet(pbm); got != 11 {
+		t.Errorf("Wanted 11 pixels got %d", got)
+	}
+}
+
+func TestPBMResizeEnlarge(t *testing.T) {
+	pbm := newBlankPBM(2, 2)
+	pbm.Set(1, 0, true)
+	pbm.Resize(6, 4)
+	if got := countSet(pbm); got != 6 || !pbm.data[0][3] || !pbm.data[1][5] || pbm.data[2][4] {
+		t.Errorf("Enlarging should turn each pixel into a block, got %d pixels", got)
+	}
+}
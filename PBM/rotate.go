@@ -0,0 +1,59 @@
+package Netpbm
+
+// remap replaces the image with a width × height image whose pixel (x, y) is the pixel source(x, y) of the original.
+func (pbm *PBM) remap(width, height int, source func(x, y int) (int, int)) {
+	data := make([][]bool, height)
+	for y := range data {
+		data[y] = make([]bool, width)
+		for x := range data[y] {
+			sx, sy := source(x, y)
+			data[y][x] = pbm.data[sy][sx]
+		}
+	}
+	pbm.data = data
+	pbm.width, pbm.height = width, height
+}
+
+// Rotate90CW rotates the image by 90° clockwise.
+func (pbm *PBM) Rotate90CW() {
+	h := pbm.height
+	pbm.remap(pbm.height, pbm.width, func(x, y int) (int, int) {
+		return y, h - 1 - x
+	})
+}
+
+// Rotate90CCW rotates the image by 90° counterclockwise.
+func (pbm *PBM) Rotate90CCW() {
+	w := pbm.width
+	pbm.remap(pbm.height, pbm.width, func(x, y int) (int, int) {
+		return w - 1 - y, x
+	})
+}
+
+// Rotate180 rotates the image by 180°.
+func (pbm *PBM) Rotate180() {
+	w, h := pbm.width, pbm.height
+	pbm.remap(w, h, func(x, y int) (int, int) {
+		return w - 1 - x, h - 1 - y
+	})
+}
+
+// Rotate270CW rotates the image by 270° clockwise, like Rotate90CCW.
+func (pbm *PBM) Rotate270CW() {
+	pbm.Rotate90CCW()
+}
+
+// Transpose swaps rows and columns: the image is mirrored along its main diagonal.
+func (pbm *PBM) Transpose() {
+	pbm.remap(pbm.height, pbm.width, func(x, y int) (int, int) {
+		return y, x
+	})
+}
+
+// Transverse mirrors the image along its anti-diagonal, from the top-right to the bottom-left corner.
+func (pbm *PBM) Transverse() {
+	w, h := pbm.width, pbm.height
+	pbm.remap(h, w, func(x, y int) (int, int) {
+		return w - 1 - y, h - 1 - x
+	})
+}
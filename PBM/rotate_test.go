@@ -0,0 +1,39 @@
+package Netpbm
+
+import "testing"
+
+func TestPBMOrthogonalTransforms(t *testing.T) {
+	// An L shape: the top row and the left column of a 3 × 2 image
+	newL := func() *PBM {
+		pbm := newBlankPBM(3, 2)
+		pbm.data = [][]bool{{true, true, true}, {true, false, false}}
+		return pbm
+	}
+	tests := []struct {
+		name      string
+		transform func(*PBM)
+		want      [][]bool
+	}{
+		{"Rotate90CW", (*PBM).Rotate90CW, [][]bool{{true, true}, {false, true}, {false, true}}},
+		{"Rotate90CCW", (*PBM).Rotate90CCW, [][]bool{{true, false}, {true, false}, {true, true}}},
+		{"Rotate180", (*PBM).Rotate180, [][]bool{{false, false, true}, {true, true, true}}},
+		{"Rotate270CW", (*PBM).Rotate270CW, [][]bool{{true, false}, {true, false}, {true, true}}},
+		{"Transpose", (*PBM).Transpose, [][]bool{{true, true}, {true, false}, {true, false}}},
+		{"Transverse", (*PBM).Transverse, [][]bool{{false, true}, {false, true}, {true, true}}},
+	}
+	for _, test := range tests {
+		pbm := newL()
+		test.transform(pbm)
+		if pbm.height != len(test.want) || pbm.width != len(test.want[0]) {
+			t.Errorf("%s: wrong size %dx%d", test.name, pbm.width, pbm.height)
+			continue
+		}
+		for y := range test.want {
+			for x := range test.want[y] {
+				if pbm.data[y][x] != test.want[y][x] {
+					t.Errorf("%s: wanted %v got %v", test.name, test.want, pbm.data)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,186 @@
+package Netpbm
+
+import (
+	"math"
+	"sort"
+)
+
+// ThinningMethod is the algorithm used to reduce strokes to one pixel wide skeletons.
+type ThinningMethod int
+
+const (
+	// ThinningZhangSuen is the classic algorithm of Zhang and Suen. It may leave
+	// two pixel wide diagonal stairs.
+	ThinningZhangSuen ThinningMethod = iota
+	// ThinningGuoHall is the algorithm of Guo and Hall, which gives thinner diagonals.
+	ThinningGuoHall
+)
+
+// neighbors returns the eight neighbors of (x, y), starting with the one above and turning clockwise.
+// Pixels outside the image are unset.
+func (pbm *PBM) neighbors(x, y int) [8]bool {
+	return [8]bool{
+		pbm.At(x, y-1), pbm.At(x+1, y-1), pbm.At(x+1, y), pbm.At(x+1, y+1),
+		pbm.At(x, y+1), pbm.At(x-1, y+1), pbm.At(x-1, y), pbm.At(x-1, y-1),
+	}
+}
+
+// Thin reduces the set regions of the image to one pixel wide skeletons that keep their topology.
+func (pbm *PBM) Thin(method ThinningMethod) {
+	remove := zhangSuenRemovable
+	if method == ThinningGuoHall {
+		remove = guoHallRemovable
+	}
+	var marked []Point
+	for changed := true; changed; {
+		changed = false
+		for pass := 0; pass < 2; pass++ {
+			marked = marked[:0]
+			for y := 0; y < pbm.height; y++ {
+				for x := 0; x < pbm.width; x++ {
+					if pbm.data[y][x] && remove(pbm.neighbors(x, y), pass) {
+						marked = append(marked, Point{x, y})
+					}
+				}
+			}
+			// The pixels of a pass are removed together, once all of them have been examined
+			for _, p := range marked {
+				pbm.data[p.Y][p.X] = false
+			}
+			changed = changed || len(marked) > 0
+		}
+	}
+}
+
+func b2i(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// zhangSuenRemovable tells if a pixel with the given neighbors is removed by the given pass of Zhang-Suen.
+func zhangSuenRemovable(n [8]bool, pass int) bool {
+	p2, p4, p6, p8 := n[0], n[2], n[4], n[6]
+	count, transitions := 0, 0
+	for i := range n {
+		count += b2i(n[i])
+		if !n[i] && n[(i+1)%8] {
+			transitions++
+		}
+	}
+	if count < 2 || count > 6 || transitions != 1 {
+		return false
+	}
+	if pass == 0 {
+		return !(p2 && p4 && p6) && !(p4 && p6 && p8)
+	}
+	return !(p2 && p4 && p8) && !(p2 && p6 && p8)
+}
+
+// guoHallRemovable tells if a pixel with the given neighbors is removed by the given pass of Guo-Hall.
+func guoHallRemovable(n [8]bool, pass int) bool {
+	p2, p3, p4, p5, p6, p7, p8, p9 := n[0], n[1], n[2], n[3], n[4], n[5], n[6], n[7]
+	c := b2i(!p2 && (p3 || p4)) + b2i(!p4 && (p5 || p6)) + b2i(!p6 && (p7 || p8)) + b2i(!p8 && (p9 || p2))
+	n1 := b2i(p9 || p2) + b2i(p3 || p4) + b2i(p5 || p6) + b2i(p7 || p8)
+	n2 := b2i(p2 || p3) + b2i(p4 || p5) + b2i(p6 || p7) + b2i(p8 || p9)
+	count := min(n1, n2)
+	var m bool
+	if pass == 0 {
+		m = (p6 || p7 || !p9) && p8
+	} else {
+		m = (p2 || p3 || !p5) && p4
+	}
+	return c == 1 && count >= 2 && count <= 3 && !m
+}
+
+// simple tells if removing a pixel with the given neighbors keeps the topology of the image,
+// using the Yokoi connectivity number for 8-connected foreground.
+func simple(n [8]bool) bool {
+	// Neighbors in the order east, north-east, north, north-west, west, ...
+	ring := [8]bool{n[2], n[1], n[0], n[7], n[6], n[5], n[4], n[3]}
+	number := 0
+	for k := 0; k < 8; k += 2 {
+		a, b, c := !ring[k], !ring[(k+1)%8], !ring[(k+2)%8]
+		number += b2i(a) - b2i(a && b && c)
+	}
+	return number == 1
+}
+
+// SkeletonPoint is a pixel of a medial axis with its distance to the nearest unset pixel.
+type SkeletonPoint struct {
+	Point
+	Distance float64
+}
+
+// MedialAxis reduces the set regions of the image to their medial axis and returns its pixels, in
+// reading order, with their Euclidean distance to the nearest unset pixel or to the outside of the image.
+// The distance is the local half-width of the stroke. The centers of maximal disks are kept, the other
+// pixels are removed in order of increasing distance as long as the topology is kept and they are not
+// stroke ends; a final thinning makes the axis one pixel wide.
+func (pbm *PBM) MedialAxis() []SkeletonPoint {
+	w, h := pbm.width, pbm.height
+	// The unset border around the image makes its outside count as background
+	squared := squaredDistances(w+2, h+2, func(x, y int) bool {
+		return !pbm.At(x-1, y-1)
+	})
+	distance := func(p Point) float64 {
+		return math.Sqrt(squared[(p.Y+1)*(w+2)+p.X+1])
+	}
+	// A pixel is kept when it is the center of a maximal disk: no neighbor has a free disk
+	// large enough to contain its own
+	ridge := func(p Point) bool {
+		r := distance(p)
+		for dy := -1; dy <= 1; dy++ {
+			for dx := -1; dx <= 1; dx++ {
+				q := Point{p.X + dx, p.Y + dy}
+				if (dx != 0 || dy != 0) && pbm.At(q.X, q.Y) && distance(q) >= r+math.Hypot(float64(dx), float64(dy))-1e-9 {
+					return false
+				}
+			}
+		}
+		return true
+	}
+
+	var candidates []Point
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			if pbm.data[y][x] && !ridge(Point{x, y}) {
+				candidates = append(candidates, Point{x, y})
+			}
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return distance(candidates[i]) < distance(candidates[j])
+	})
+
+	for changed := true; changed; {
+		changed = false
+		kept := candidates[:0]
+		for _, p := range candidates {
+			n := pbm.neighbors(p.X, p.Y)
+			count := 0
+			for _, v := range n {
+				count += b2i(v)
+			}
+			if count > 1 && simple(n) {
+				pbm.data[p.Y][p.X] = false
+				changed = true
+				continue
+			}
+			kept = append(kept, p)
+		}
+		candidates = kept
+	}
+	pbm.Thin(ThinningGuoHall)
+
+	var skeleton []SkeletonPoint
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			if pbm.data[y][x] {
+				skeleton = append(skeleton, SkeletonPoint{Point{x, y}, distance(Point{x, y})})
+			}
+		}
+	}
+	return skeleton
+}
@@ -0,0 +1,86 @@
+package Netpbm
+
+import (
+	"math"
+	"testing"
+)
+
+// neighborCount returns the number of set neighbors of (x, y).
+func neighborCount(pbm *PBM, x, y int) int {
+	count := 0
+	for _, v := range pbm.neighbors(x, y) {
+		count += b2i(v)
+	}
+	return count
+}
+
+func TestPBMThin(t *testing.T) {
+	for _, method := range []ThinningMethod{ThinningZhangSuen, ThinningGuoHall} {
+		pbm := newBlankPBM(30, 15)
+		pbm.DrawFilledRectangle(Point{3, 5}, 24, 5, ModeSet)
+		pbm.Thin(method)
+
+		// A horizontal line remains, one pixel thick, on the middle row
+		for x := 6; x < 24; x++ {
+			if !pbm.data[7][x] || pbm.data[6][x] || pbm.data[8][x] {
+				t.Fatalf("method %d: column %d is not thinned to the middle row", method, x)
+			}
+		}
+		for y := 0; y < pbm.height; y++ {
+			for x := 0; x < pbm.width; x++ {
+				if pbm.data[y][x] && neighborCount(pbm, x, y) == 0 {
+					t.Fatalf("method %d: isolated pixel at (%d, %d)", method, x, y)
+				}
+			}
+		}
+	}
+}
+
+func TestPBMThinKeepsTopology(t *testing.T) {
+	for _, method := range []ThinningMethod{ThinningZhangSuen, ThinningGuoHall} {
+		// A thick ring must stay a closed loop
+		pbm := newBlankPBM(30, 30)
+		pbm.DrawFilledCircle(Point{15, 15}, 12, ModeSet)
+		pbm.DrawFilledCircle(Point{15, 15}, 6, ModeClear)
+		pbm.Thin(method)
+		if pbm.data[15][15] {
+			t.Fatalf("method %d: the hole was filled", method)
+		}
+		for y := 0; y < pbm.height; y++ {
+			for x := 0; x < pbm.width; x++ {
+				if pbm.data[y][x] && neighborCount(pbm, x, y) < 2 {
+					t.Fatalf("method %d: the ring was broken at (%d, %d)", method, x, y)
+				}
+			}
+		}
+		// Filling from the center must not leak outside the loop
+		pbm.FloodFill(Point{15, 15}, true, Connect4)
+		if pbm.data[0][0] {
+			t.Fatalf("method %d: the ring is not closed", method)
+		}
+	}
+}
+
+func TestPBMMedialAxis(t *testing.T) {
+	pbm := newBlankPBM(30, 15)
+	pbm.DrawFilledRectangle(Point{3, 4}, 24, 7, ModeSet)
+	skeleton := pbm.MedialAxis()
+	if len(skeleton) == 0 {
+		t.Fatal("Empty medial axis")
+	}
+	onMiddle := 0
+	for _, p := range skeleton {
+		if !pbm.data[p.Y][p.X] {
+			t.Fatalf("Skeleton point %v is not set in the image", p)
+		}
+		if p.Y == 7 && p.X >= 8 && p.X <= 21 {
+			onMiddle++
+			if math.Abs(p.Distance-4) > 1e-9 {
+				t.Fatalf("Distance at %v = %v, want 4", p.Point, p.Distance)
+			}
+		}
+	}
+	if onMiddle != 14 {
+		t.Fatalf("The middle of the bar should be on the axis, got %d pixels", onMiddle)
+	}
+}
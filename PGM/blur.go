@@ -0,0 +1,45 @@
+package Netpbm
+
+import (
+	"fmt"
+
+	"github.com/dada416-lebg/Netpbm/internal/raster"
+)
+
+// GaussianKernel renvoie le noyau gaussien séparable d'écart type sigma, de rayon ceil(3 × sigma)
+// et de somme 1.
+func GaussianKernel(sigma float64) Kernel {
+	return raster.GaussianKernel(sigma)
+}
+
+// GaussianBlur applique un flou gaussien d'écart type sigma, en pixels. Les bords sont prolongés.
+func (pgm *PGM) GaussianBlur(sigma float64) {
+	if sigma <= 0 {
+		fmt.Println("L'écart type du flou doit être positif.")
+		return
+	}
+	pgm.Convolve(GaussianKernel(sigma), ConvolveOptions{Edge: EdgeReplicate})
+}
+
+// BoxBlur remplace chaque pixel par la moyenne du carré de côté 2 × radius + 1 qui l'entoure.
+// Le calcul utilise une somme glissante : son coût ne dépend pas du rayon. Les bords sont prolongés.
+func (pgm *PGM) BoxBlur(radius int) {
+	if radius <= 0 {
+		fmt.Println("Le rayon du flou doit être positif.")
+		return
+	}
+	pgm.setPlane(raster.BoxBlurPlane(pgm.plane(), pgm.width, pgm.height, radius), 0)
+}
+
+// UnsharpMask accentue les détails en ajoutant amount fois la différence entre l'image et son flou
+// gaussien d'écart type sigma. Les différences inférieures à threshold ne sont pas accentuées,
+// ce qui évite d'amplifier le bruit des zones uniformes.
+func (pgm *PGM) UnsharpMask(sigma, amount float64, threshold uint8) {
+	if sigma <= 0 {
+		fmt.Println("L'écart type du flou doit être positif.")
+		return
+	}
+	plane := pgm.plane()
+	blurred := raster.ConvolvePlane(plane, pgm.width, pgm.height, GaussianKernel(sigma), EdgeReplicate, 0)
+	pgm.setPlane(raster.UnsharpPlane(plane, blurred, amount, float64(threshold)), 0)
+}
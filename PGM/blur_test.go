@@ -0,0 +1,69 @@
+package Netpbm
+
+import (
+	"math"
+	"testing"
+)
+
+func TestPGMGaussianKernel(t *testing.T) {
+	k := GaussianKernel(1)
+	if k.Width != 7 || !k.Separable() || math.Abs(k.Sum()-1) > 1e-9 {
+		t.Fatalf("Gaussian kernel wrong: %d×%d, sum %v", k.Width, k.Height, k.Sum())
+	}
+	if k.Values[3*7+3] <= k.Values[3*7+2] {
+		t.Fatal("Gaussian kernel should peak at its center")
+	}
+}
+
+func TestPGMBoxBlur(t *testing.T) {
+	pgm := newBlankPGM(9, 9)
+	pgm.data[4][4] = 225
+	direct := newBlankPGM(9, 9)
+	direct.data[4][4] = 225
+
+	pgm.BoxBlur(1)
+	direct.Convolve(BoxKernel(3), ConvolveOptions{Edge: EdgeReplicate})
+	if countValue(pgm, 25) != 9 {
+		t.Fatalf("BoxBlur should spread the point over 3×3 pixels: %v", pgm.data)
+	}
+	for y := range pgm.data {
+		for x := range pgm.data[y] {
+			if pgm.data[y][x] != direct.data[y][x] {
+				t.Fatalf("BoxBlur differs from BoxKernel at (%d, %d)", x, y)
+			}
+		}
+	}
+}
+
+func TestPGMGaussianBlur(t *testing.T) {
+	pgm := newBlankPGM(11, 11)
+	pgm.data[5][5] = 255
+	pgm.GaussianBlur(1.5)
+	if pgm.data[5][5] >= 255 || pgm.data[5][5] == 0 || pgm.data[5][6] == 0 || pgm.data[5][6] > pgm.data[5][5] {
+		t.Fatalf("GaussianBlur wrong around the point: %v", pgm.data[5])
+	}
+}
+
+func TestPGMUnsharpMask(t *testing.T) {
+	pgm := newBlankPGM(10, 4)
+	for _, row := range pgm.data {
+		for x := range row {
+			row[x] = 100
+			if x >= 5 {
+				row[x] = 150
+			}
+		}
+	}
+	pgm.UnsharpMask(1, 1, 0)
+	if pgm.data[1][4] >= 100 || pgm.data[1][5] <= 150 || pgm.data[1][0] != 100 || pgm.data[1][9] != 150 {
+		t.Fatalf("UnsharpMask should increase the step contrast: %v", pgm.data[1])
+	}
+
+	flat := newBlankPGM(10, 4)
+	flat.data = [][]uint8{{100, 101, 100, 101, 100, 101, 100, 101, 100, 101}}
+	flat.height = 1
+	flat.UnsharpMask(1, 2, 5)
+	if flat.data[0][0] != 100 || flat.data[0][1] != 101 {
+		t.Fatalf("Threshold should protect small differences: %v", flat.data)
+	}
+}
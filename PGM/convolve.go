@@ -0,0 +1,89 @@
+package Netpbm
+
+import (
+	"math"
+
+	"github.com/dada416-lebg/Netpbm/internal/raster"
+)
+
+// Kernel est un noyau de convolution de Width × Height coefficients, rangés ligne par ligne.
+// Le pixel traité est placé au centre du noyau (Width/2, Height/2).
+type Kernel = raster.Kernel
+
+// NewKernel crée un noyau à partir de ses lignes, qui doivent toutes avoir la même longueur.
+// Un noyau séparable est reconnu automatiquement et appliqué en deux passes, une horizontale et une verticale.
+func NewKernel(rows [][]float64) Kernel {
+	return raster.NewKernel(rows)
+}
+
+// NewSeparableKernel crée le noyau produit d'une colonne et d'une ligne : la ligne est appliquée
+// horizontalement puis la colonne verticalement.
+func NewSeparableKernel(row, column []float64) Kernel {
+	return raster.NewSeparableKernel(row, column)
+}
+
+// BoxKernel renvoie le noyau moyenneur de taille size × size.
+func BoxKernel(size int) Kernel {
+	return raster.BoxKernel(size)
+}
+
+// SharpenKernel renvoie un noyau 3 × 3 qui accentue les contours.
+func SharpenKernel() Kernel {
+	return raster.SharpenKernel()
+}
+
+// EmbossKernel renvoie un noyau 3 × 3 d'estampage, éclairé depuis le coin supérieur gauche.
+// Il s'utilise avec un décalage Bias de la moitié de la valeur maximale.
+func EmbossKernel() Kernel {
+	return raster.EmbossKernel()
+}
+
+// ConvolveOptions regroupe les paramètres d'une convolution.
+type ConvolveOptions struct {
+	// Edge définit les pixels utilisés au-delà des bords de l'image.
+	Edge EdgeMode
+	// Fill est la valeur des pixels hors de l'image avec EdgeConstant.
+	Fill uint8
+	// Normalize divise le noyau par la somme de ses coefficients.
+	Normalize bool
+	// Bias est ajouté au résultat, par exemple pour centrer un estampage sur le gris moyen.
+	Bias float64
+}
+
+// Convolve applique le noyau à l'image. Le noyau est appliqué tel quel, sans être retourné,
+// comme le font la plupart des logiciels de retouche. Les résultats sont arrondis et ramenés
+// entre 0 et la valeur maximale de l'image.
+func (pgm *PGM) Convolve(k Kernel, options ConvolveOptions) {
+	if k.Width == 0 || k.Height == 0 {
+		return
+	}
+	if options.Normalize {
+		k = k.Normalized()
+	}
+	result := raster.ConvolvePlane(pgm.plane(), pgm.width, pgm.height, k, options.Edge, float64(options.Fill))
+	pgm.setPlane(result, options.Bias)
+}
+
+// plane renvoie les valeurs de l'image sous forme de tableau de flottants, ligne par ligne.
+func (pgm *PGM) plane() []float64 {
+	plane := make([]float64, pgm.width*pgm.height)
+	for y := 0; y < pgm.height; y++ {
+		for x := 0; x < pgm.width; x++ {
+			plane[y*pgm.width+x] = float64(pgm.data[y][x])
+		}
+	}
+	return plane
+}
+
+// setPlane remplace les valeurs de l'image par celles du tableau augmentées de bias, arrondies et bornées.
+func (pgm *PGM) setPlane(plane []float64, bias float64) {
+	maxValue := float64(pgm.max)
+	if pgm.max <= 0 {
+		maxValue = 255
+	}
+	for y := 0; y < pgm.height; y++ {
+		for x := 0; x < pgm.width; x++ {
+			pgm.data[y][x] = toUint8(math.Min(plane[y*pgm.width+x]+bias, maxValue))
+		}
+	}
+}
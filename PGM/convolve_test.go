@@ -0,0 +1,78 @@
+package Netpbm
+
+import "testing"
+
+func TestPGMKernelSeparable(t *testing.T) {
+	gauss := NewKernel([][]float64{{1, 2, 1}, {2, 4, 2}, {1, 2, 1}})
+	if !gauss.Separable() {
+		t.Fatal("Gaussian kernel should be separable")
+	}
+	if SharpenKernel().Separable() {
+		t.Fatal("Sharpen kernel should not be separable")
+	}
+	if n := gauss.Normalized(); n.Sum() < 0.999999 || n.Sum() > 1.000001 {
+		t.Fatalf("Normalized sum = %v", n.Sum())
+	}
+	if k := NewKernel([][]float64{{1, 2}, {3}}); k.Width != 0 {
+		t.Fatal("Ragged kernel should be rejected")
+	}
+}
+
+func TestPGMConvolveSeparableMatchesDirect(t *testing.T) {
+	rows := [][]float64{{1, 2, 1}, {2, 4, 2}, {1, 2, 1}}
+	separable := NewKernel(rows)
+	direct := Kernel{Width: 3, Height: 3, Values: separable.Values}
+
+	for _, edge := range []EdgeMode{EdgeConstant, EdgeReplicate, EdgeReflect, EdgeWrap} {
+		a, b := newBlankPGM(7, 5), newBlankPGM(7, 5)
+		for y := 0; y < 5; y++ {
+			for x := 0; x < 7; x++ {
+				a.data[y][x] = uint8((x*37 + y*91) % 256)
+				b.data[y][x] = a.data[y][x]
+			}
+		}
+		options := ConvolveOptions{Edge: edge, Fill: 50, Normalize: true}
+		a.Convolve(separable, options)
+		b.Convolve(direct, options)
+		for y := range a.data {
+			for x := range a.data[y] {
+				if a.data[y][x] != b.data[y][x] {
+					t.Fatalf("edge %d: (%d, %d) separable %d, direct %d", edge, x, y, a.data[y][x], b.data[y][x])
+				}
+			}
+		}
+	}
+}
+
+func TestPGMConvolveFlat(t *testing.T) {
+	for _, edge := range []EdgeMode{EdgeReplicate, EdgeReflect, EdgeWrap} {
+		pgm := newBlankPGM(6, 6)
+		for _, row := range pgm.data {
+			for x := range row {
+				row[x] = 100
+			}
+		}
+		pgm.Convolve(BoxKernel(5), ConvolveOptions{Edge: edge})
+		pgm.Convolve(SharpenKernel(), ConvolveOptions{Edge: edge})
+		if countValue(pgm, 100) != 36 {
+			t.Fatalf("edge %d: flat image changed: %v", edge, pgm.data)
+		}
+		pgm.Convolve(EmbossKernel(), ConvolveOptions{Edge: edge, Bias: 128})
+		if countValue(pgm, 128) != 36 {
+			t.Fatalf("edge %d: emboss of flat image should be 128: %v", edge, pgm.data)
+		}
+	}
+}
+
+func TestPGMConvolveConstantEdge(t *testing.T) {
+	pgm := newBlankPGM(3, 3)
+	for _, row := range pgm.data {
+		for x := range row {
+			row[x] = 90
+		}
+	}
+	pgm.Convolve(BoxKernel(3), ConvolveOptions{Edge: EdgeConstant, Fill: 0})
+	if pgm.data[1][1] != 90 || pgm.data[0][0] != 40 || pgm.data[0][1] != 60 {
+		t.Fatalf("Constant edge wrong: %v", pgm.data)
+	}
+}
@@ -0,0 +1,110 @@
+package Netpbm
+
+import (
+	"fmt"
+
+	"github.com/dada416-lebg/Netpbm/internal/raster"
+)
+
+// Rect est un rectangle de pixels de coin supérieur gauche (X, Y).
+type Rect struct {
+	X, Y, Width, Height int
+}
+
+// Empty indique si le rectangle ne contient aucun pixel.
+func (r Rect) Empty() bool {
+	return r.Width <= 0 || r.Height <= 0
+}
+
+// Intersect renvoie l'intersection des deux rectangles, de taille nulle s'ils sont disjoints.
+func (r Rect) Intersect(s Rect) Rect {
+	x0, y0 := max(r.X, s.X), max(r.Y, s.Y)
+	x1, y1 := min(r.X+r.Width, s.X+s.Width), min(r.Y+r.Height, s.Y+s.Height)
+	if x1 <= x0 || y1 <= y0 {
+		return Rect{x0, y0, 0, 0}
+	}
+	return Rect{x0, y0, x1 - x0, y1 - y0}
+}
+
+// EdgeMode définit la valeur des pixels situés hors de l'image.
+type EdgeMode = raster.EdgeMode
+
+const (
+	// EdgeConstant utilise une couleur fixe.
+	EdgeConstant = raster.EdgeConstant
+	// EdgeReplicate prolonge le pixel du bord le plus proche.
+	EdgeReplicate = raster.EdgeReplicate
+	// EdgeReflect reflète l'image comme dans un miroir posé sur le bord, pixel du bord compris (dcba|abcd|dcba).
+	EdgeReflect = raster.EdgeReflect
+	// EdgeWrap répète l'image comme une mosaïque.
+	EdgeWrap = raster.EdgeWrap
+)
+
+// atEdge renvoie la valeur du pixel (x, y), éventuellement hors de l'image, selon le mode de bord.
+func (pgm *PGM) atEdge(x, y int, mode EdgeMode, fill uint8) uint8 {
+	sx, okX := raster.EdgeIndex(x, pgm.width, mode)
+	sy, okY := raster.EdgeIndex(y, pgm.height, mode)
+	if !okX || !okY {
+		return fill
+	}
+	return pgm.data[sy][sx]
+}
+
+// Crop ne garde que la partie de l'image située dans le rectangle r.
+func (pgm *PGM) Crop(r Rect) {
+	r = r.Intersect(Rect{0, 0, pgm.width, pgm.height})
+	if r.Empty() {
+		fmt.Println("La zone à découper est en dehors de l'image.")
+		return
+	}
+	data := make([][]uint8, r.Height)
+	for y := range data {
+		data[y] = append([]uint8(nil), pgm.data[r.Y+y][r.X:r.X+r.Width]...)
+	}
+	pgm.data = data
+	pgm.width, pgm.height = r.Width, r.Height
+}
+
+// Pad ajoute autour de l'image des bordures de niveau de gris fill, de l'épaisseur donnée pour chaque côté.
+func (pgm *PGM) Pad(top, right, bottom, left int, fill uint8) {
+	pgm.Extend(top, right, bottom, left, EdgeConstant, fill)
+}
+
+// Extend agrandit l'image de l'épaisseur donnée pour chaque côté. Les nouveaux pixels sont obtenus
+// selon le mode de bord ; fill n'est utilisé qu'avec EdgeConstant.
+func (pgm *PGM) Extend(top, right, bottom, left int, mode EdgeMode, fill uint8) {
+	if top < 0 || right < 0 || bottom < 0 || left < 0 {
+		fmt.Println("Les épaisseurs des bordures doivent être positives.")
+		return
+	}
+	width, height := pgm.width+left+right, pgm.height+top+bottom
+	data := make([][]uint8, height)
+	for y := range data {
+		data[y] = make([]uint8, width)
+		for x := range data[y] {
+			data[y][x] = pgm.atEdge(x-left, y-top, mode, fill)
+		}
+	}
+	pgm.data = data
+	pgm.width, pgm.height = width, height
+}
+
+// Trim retire les bordures uniformes de l'image, du niveau de gris du pixel en haut à gauche, et renvoie
+// le rectangle conservé. Un pixel fait partie de la bordure s'il ne s'écarte pas de plus de tolerance
+// de ce niveau. Une image entièrement uniforme n'est pas modifiée.
+func (pgm *PGM) Trim(tolerance int) Rect {
+	if pgm.width == 0 || pgm.height == 0 {
+		return Rect{}
+	}
+	border := pgm.data[0][0]
+	isBorder := func(x, y int) bool {
+		return absInt(int(pgm.data[y][x])-int(border)) <= tolerance
+	}
+	x, y, w, h, ok := raster.ContentBounds(pgm.width, pgm.height, isBorder)
+	if !ok {
+		return Rect{0, 0, pgm.width, pgm.height}
+	}
+	r := Rect{x, y, w, h}
+	pgm.Crop(r)
+	return r
+}
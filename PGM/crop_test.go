@@ -0,0 +1,47 @@
+package Netpbm
+
+import "testing"
+
+func TestPGMCropAndExtend(t *testing.T) {
+	pgm := newBlankPGM(4, 3)
+	pgm.data = [][]uint8{{1, 2, 3, 4}, {5, 6, 7, 8}, {9, 10, 11, 12}}
+	pgm.Crop(Rect{1, 1, 2, 2})
+	if pgm.width != 2 || pgm.height != 2 || pgm.data[0][0] != 6 || pgm.data[1][1] != 11 {
+		t.Fatalf("Crop wrong: %v", pgm.data)
+	}
+
+	pgm.Extend(1, 1, 1, 1, EdgeReflect, 0)
+	want := [][]uint8{{6, 6, 7, 7}, {6, 6, 7, 7}, {10, 10, 11, 11}, {10, 10, 11, 11}}
+	for y := range want {
+		for x := range want[y] {
+			if pgm.data[y][x] != want[y][x] {
+				t.Fatalf("Reflect wrong: %v", pgm.data)
+			}
+		}
+	}
+
+	pgm.Pad(0, 0, 2, 0, 255)
+	if pgm.height != 6 || countValue(pgm, 255) != 8 {
+		t.Error("Pad wrong")
+	}
+}
+
+func TestPGMTrim(t *testing.T) {
+	pgm := newBlankPGM(10, 10)
+	pgm.DrawFilledRectangle(Point{0, 0}, 10, 10, 240)
+	pgm.data[9][9] = 236
+	pgm.DrawLine(Point{2, 4}, Point{6, 4}, 20)
+	if r := pgm.Trim(5); r != (Rect{2, 4, 5, 1}) || countValue(pgm, 20) != 5 {
+		t.Errorf("Trim kept %v", r)
+	}
+}
+
+func TestPGMExtendEmpty(t *testing.T) {
+	for _, mode := range []EdgeMode{EdgeConstant, EdgeReplicate, EdgeReflect, EdgeWrap} {
+		pgm := newBlankPGM(0, 0)
+		pgm.Extend(1, 2, 1, 2, mode, 9)
+		if pgm.width != 4 || pgm.height != 2 || countValue(pgm, 9) != 8 {
+			t.Errorf("Mode %d: extending an empty image should fill it, got %dx%d", mode, pgm.width, pgm.height)
+		}
+	}
+}
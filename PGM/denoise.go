@@ -0,0 +1,40 @@
+package Netpbm
+
+import (
+	"fmt"
+
+	"github.com/dada416-lebg/Netpbm/internal/raster"
+)
+
+// Bilateral lisse l'image en préservant les contours : chaque pixel devient la moyenne de ses voisins,
+// pondérée à la fois par leur distance (écart type spatialSigma, en pixels) et par leur différence
+// de valeur (écart type rangeSigma, dans les unités des valeurs de l'image). Les lignes sont traitées
+// en parallèle.
+func (pgm *PGM) Bilateral(spatialSigma, rangeSigma float64) {
+	if spatialSigma <= 0 || rangeSigma <= 0 {
+		fmt.Println("Les écarts types du filtre bilatéral doivent être positifs.")
+		return
+	}
+	planes := raster.BilateralPlanes([][]float64{pgm.plane()}, pgm.width, pgm.height, spatialSigma, rangeSigma)
+	pgm.setPlane(planes[0], 0)
+}
+
+// NonLocalMeansOptions regroupe les paramètres du débruitage par moyennes non locales.
+type NonLocalMeansOptions = raster.NonLocalMeansOptions
+
+// DefaultNonLocalMeansOptions renvoie des paramètres adaptés à un bruit modéré sur une image 8 bits.
+func DefaultNonLocalMeansOptions() NonLocalMeansOptions {
+	return NonLocalMeansOptions{PatchRadius: 1, SearchRadius: 7, H: 10}
+}
+
+// NonLocalMeans débruite l'image en remplaçant chaque pixel par la moyenne des pixels de la zone
+// de recherche, pondérée par la ressemblance de leurs voisinages avec celui du pixel. Les lignes
+// sont traitées en parallèle.
+func (pgm *PGM) NonLocalMeans(options NonLocalMeansOptions) {
+	if options.PatchRadius < 0 || options.SearchRadius <= 0 || options.H <= 0 {
+		fmt.Println("Les paramètres des moyennes non locales ne sont pas valides.")
+		return
+	}
+	planes := raster.NonLocalMeansPlanes([][]float64{pgm.plane()}, pgm.width, pgm.height, options)
+	pgm.setPlane(planes[0], 0)
+}
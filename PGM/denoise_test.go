@@ -0,0 +1,73 @@
+package Netpbm
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// noisyStepPGM crée une marche de 60 à 190 au milieu de l'image, avec un bruit uniforme de ±8.
+func noisyStepPGM(seed int64) *PGM {
+	rng := rand.New(rand.NewSource(seed))
+	pgm := newBlankPGM(24, 16)
+	for _, row := range pgm.data {
+		for x := range row {
+			v := 60
+			if x >= 12 {
+				v = 190
+			}
+			row[x] = uint8(v + rng.Intn(17) - 8)
+		}
+	}
+	return pgm
+}
+
+// stepError renvoie l'écart absolu moyen à la marche idéale, ainsi que le plus grand écart.
+func stepError(pgm *PGM) (float64, int) {
+	total, worst := 0, 0
+	for _, row := range pgm.data {
+		for x, v := range row {
+			want := 60
+			if x >= 12 {
+				want = 190
+			}
+			d := int(v) - want
+			if d < 0 {
+				d = -d
+			}
+			total += d
+			worst = max(worst, d)
+		}
+	}
+	return float64(total) / float64(pgm.width*pgm.height), worst
+}
+
+func TestPGMBilateral(t *testing.T) {
+	pgm := noisyStepPGM(1)
+	before, _ := stepError(pgm)
+	pgm.Bilateral(2, 20)
+	after, worst := stepError(pgm)
+	if after >= before/2 {
+		t.Fatalf("Bilateral should reduce the noise: %.2f -> %.2f", before, after)
+	}
+	if worst > 10 {
+		t.Fatalf("Bilateral should keep the step sharp, worst error %d", worst)
+	}
+
+	pgm.Bilateral(0, 20)
+	if after2, _ := stepError(pgm); after2 != after {
+		t.Fatal("Invalid sigma should leave the image unchanged")
+	}
+}
+
+func TestPGMNonLocalMeans(t *testing.T) {
+	pgm := noisyStepPGM(2)
+	before, _ := stepError(pgm)
+	pgm.NonLocalMeans(DefaultNonLocalMeansOptions())
+	after, worst := stepError(pgm)
+	if after >= before/2 {
+		t.Fatalf("NonLocalMeans should reduce the noise: %.2f -> %.2f", before, after)
+	}
+	if worst > 10 {
+		t.Fatalf("NonLocalMeans should keep the step sharp, worst error %d", worst)
+	}
+}
@@ -0,0 +1,110 @@
+package Netpbm
+
+import (
+	"fmt"
+
+	"github.com/dada416-lebg/Netpbm/internal/raster"
+)
+
+// DrawLine trace un segment entre p1 et p2 avec le niveau de gris value.
+// Les pixels hors de l'image sont ignorés.
+func (pgm *PGM) DrawLine(p1, p2 Point, value uint8) {
+	bresenham(p1, p2, func(x, y int) {
+		pgm.plot(x, y, value)
+	})
+}
+
+// bresenham appelle plot pour chaque pixel du segment allant de p1 à p2.
+func bresenham(p1, p2 Point, plot func(x, y int)) {
+	raster.Line(p1.X, p1.Y, p2.X, p2.Y, plot)
+}
+
+// DrawRectangle trace le contour du rectangle de coin supérieur gauche p1.
+func (pgm *PGM) DrawRectangle(p1 Point, width, height int, value uint8) {
+	if width <= 0 || height <= 0 {
+		fmt.Println("Les dimensions du rectangle ne sont pas valides.")
+		return
+	}
+	x2, y2 := p1.X+width-1, p1.Y+height-1
+	pgm.DrawLine(p1, Point{x2, p1.Y}, value)
+	pgm.DrawLine(Point{x2, p1.Y}, Point{x2, y2}, value)
+	pgm.DrawLine(Point{x2, y2}, Point{p1.X, y2}, value)
+	pgm.DrawLine(Point{p1.X, y2}, p1, value)
+}
+
+// DrawFilledRectangle remplit le rectangle de coin supérieur gauche p1.
+// La partie du rectangle située hors de l'image est ignorée.
+func (pgm *PGM) DrawFilledRectangle(p1 Point, width, height int, value uint8) {
+	if width <= 0 || height <= 0 {
+		fmt.Println("Les dimensions du rectangle ne sont pas valides.")
+		return
+	}
+	fill := pgm.fillSpan(value)
+	x0, x1 := max(p1.X, 0), min(p1.X+width, pgm.width)
+	for y := max(p1.Y, 0); y < min(p1.Y+height, pgm.height); y++ {
+		if x0 < x1 {
+			fill(y, x0, x1)
+		}
+	}
+}
+
+// DrawCircle trace le cercle de centre center et de rayon radius.
+func (pgm *PGM) DrawCircle(center Point, radius int, value uint8) {
+	if radius <= 0 {
+		fmt.Println("Le rayon du cercle doit être positif.")
+		return
+	}
+	cx, cy := center.X, center.Y
+	raster.CircleOctant(radius, func(x, y int) {
+		// Les huit points symétriques du cercle
+		pgm.plot(cx+x, cy+y, value)
+		pgm.plot(cx+y, cy+x, value)
+		pgm.plot(cx-y, cy+x, value)
+		pgm.plot(cx-x, cy+y, value)
+		pgm.plot(cx-x, cy-y, value)
+		pgm.plot(cx-y, cy-x, value)
+		pgm.plot(cx+y, cy-x, value)
+		pgm.plot(cx+x, cy-y, value)
+	})
+}
+
+// DrawFilledCircle remplit le disque de centre center et de rayon radius.
+func (pgm *PGM) DrawFilledCircle(center Point, radius int, value uint8) {
+	if radius <= 0 {
+		fmt.Println("Le rayon du cercle doit être positif.")
+		return
+	}
+	cx, cy := center.X, center.Y
+	raster.CircleOctant(radius, func(x, y int) {
+		// Lignes horizontales entre les points symétriques
+		for _, row := range [4][2]int{{cy + y, x}, {cy - y, x}, {cy + x, y}, {cy - x, y}} {
+			pgm.DrawLine(Point{cx - row[1], row[0]}, Point{cx + row[1], row[0]}, value)
+		}
+	})
+}
+
+// DrawPolygon trace le contour fermé du polygone dont les sommets sont points.
+func (pgm *PGM) DrawPolygon(points []Point, value uint8) {
+	if len(points) < 2 {
+		fmt.Println("Le polygone doit avoir au moins 2 points.")
+		return
+	}
+	for i := range points {
+		pgm.DrawLine(points[i], points[(i+1)%len(points)], value)
+	}
+}
+
+// DrawFilledPolygon remplit le polygone dont les sommets sont points, bords compris.
+func (pgm *PGM) DrawFilledPolygon(points []Point, value uint8) {
+	if len(points) < 3 {
+		fmt.Println("Le polygone doit avoir au moins 3 points.")
+		return
+	}
+	// Les sommets désignent des centres de pixels
+	polygon := make([]PointF, len(points))
+	for i, p := range points {
+		polygon[i] = PointF{X: float64(p.X) + 0.5, Y: float64(p.Y) + 0.5}
+	}
+	raster.FillPolygons([][]PointF{polygon}, FillNonZero, pgm.width, pgm.height, pgm.fillSpan(value))
+	pgm.DrawPolygon(points, value)
+}
@@ -0,0 +1,97 @@
+package Netpbm
+
+import "testing"
+
+func countValue(pgm *PGM, value uint8) int {
+	n := 0
+	for y := range pgm.data {
+		for x := range pgm.data[y] {
+			if pgm.data[y][x] == value {
+				n++
+			}
+		}
+	}
+	return n
+}
+
+func TestPGMDrawLine(t *testing.T) {
+	pgm := newBlankPGM(10, 10)
+	pgm.DrawLine(Point{0, 0}, Point{9, 9}, 200)
+	pgm.DrawLine(Point{-5, 3}, Point{20, 3}, 100)
+	for i := 0; i < 10; i++ {
+		if i != 3 && pgm.data[i][i] != 200 {
+			t.Errorf("Diagonal pixel %d not drawn", i)
+		}
+		if pgm.data[3][i] != 100 {
+			t.Errorf("Clipped line pixel %d not drawn", i)
+		}
+	}
+	if countValue(pgm, 200)+countValue(pgm, 100) != 19 {
+		t.Error("Lines drew extra pixels")
+	}
+}
+
+func TestPGMDrawRectangles(t *testing.T) {
+	pgm := newBlankPGM(10, 10)
+	pgm.DrawRectangle(Point{1, 1}, 5, 4, 255)
+	if got := countValue(pgm, 255); got != 14 {
+		t.Errorf("Rectangle outline: wanted 14 pixels got %d", got)
+	}
+	pgm.DrawFilledRectangle(Point{7, 7}, 5, 5, 50)
+	if got := countValue(pgm, 50); got != 9 {
+		t.Errorf("Clipped filled rectangle: wanted 9 pixels got %d", got)
+	}
+}
+
+func TestPGMDrawCircles(t *testing.T) {
+	pgm := newBlankPGM(21, 21)
+	pgm.DrawCircle(Point{10, 10}, 8, 255)
+	if pgm.data[10][18] != 255 || pgm.data[2][10] != 255 || pgm.data[10][10] != 0 {
+		t.Error("Circle outline wrong")
+	}
+	filled := newBlankPGM(21, 21)
+	filled.DrawFilledCircle(Point{10, 10}, 8, 255)
+	// Le disque plein recouvre exactement le cercle et son intérieur
+	for y := range pgm.data {
+		for x := range pgm.data[y] {
+			if pgm.data[y][x] == 255 && filled.data[y][x] != 255 {
+				t.Fatalf("Filled circle misses outline pixel (%d, %d)", x, y)
+			}
+		}
+	}
+	if got := countValue(filled, 255); got < 200 || got > 235 {
+		t.Errorf("Filled circle area wrong: %d", got)
+	}
+}
+
+func TestPGMDrawPolygons(t *testing.T) {
+	pgm := newBlankPGM(10, 10)
+	square := []Point{{1, 1}, {8, 1}, {8, 8}, {1, 8}}
+	pgm.DrawPolygon(square, 255)
+	if got := countValue(pgm, 255); got != 28 {
+		t.Errorf("Polygon outline: wanted 28 pixels got %d", got)
+	}
+	pgm = newBlankPGM(10, 10)
+	pgm.DrawFilledPolygon(square, 255)
+	if got := countValue(pgm, 255); got != 64 {
+		t.Errorf("Filled polygon: wanted 64 pixels got %d", got)
+	}
+}
+
+func TestPGMPath(t *testing.T) {
+	pgm := newBlankPGM(20, 20)
+	path := NewPath()
+	path.MoveTo(2, 2)
+	path.LineTo(12, 2)
+	path.LineTo(12, 12)
+	path.LineTo(2, 12)
+	path.Close()
+	pgm.FillPath(path, 80)
+	if got := countValue(pgm, 80); got != 100 {
+		t.Errorf("FillPath: wanted 100 pixels got %d", got)
+	}
+	pgm.StrokePathWidth(path, 2, 160)
+	if pgm.data[2][7] != 160 || pgm.data[7][7] != 80 || pgm.data[0][7] != 0 {
+		t.Error("StrokePathWidth wrong")
+	}
+}
@@ -0,0 +1,216 @@
+package Netpbm
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/dada416-lebg/Netpbm/internal/raster"
+)
+
+// GradientOperator est l'opérateur de dérivation utilisé pour estimer le gradient de l'image.
+type GradientOperator int
+
+const (
+	// OperatorSobel lisse avec les poids 1, 2, 1 perpendiculairement à la dérivée.
+	OperatorSobel GradientOperator = iota
+	// OperatorPrewitt lisse avec des poids uniformes.
+	OperatorPrewitt
+	// OperatorScharr lisse avec les poids 3, 10, 3, plus fidèles à la direction du gradient.
+	OperatorScharr
+)
+
+// kernels renvoie les noyaux horizontal et vertical de l'opérateur. Ils sont divisés par la somme
+// de leurs poids positifs, de sorte qu'une marche de hauteur h donne un gradient de h.
+func (op GradientOperator) kernels() (Kernel, Kernel) {
+	smooth := []float64{1, 2, 1}
+	switch op {
+	case OperatorPrewitt:
+		smooth = []float64{1, 1, 1}
+	case OperatorScharr:
+		smooth = []float64{3, 10, 3}
+	}
+	sum := smooth[0] + smooth[1] + smooth[2]
+	for i := range smooth {
+		smooth[i] /= sum
+	}
+	derive := []float64{-1, 0, 1}
+	return NewSeparableKernel(derive, smooth), NewSeparableKernel(smooth, derive)
+}
+
+// Gradient contient les dérivées horizontale et verticale de chaque pixel, rangées ligne par ligne.
+type Gradient struct {
+	Width, Height int
+	Dx, Dy        []float64
+}
+
+// Magnitude renvoie la norme du gradient au pixel (x, y).
+func (g *Gradient) Magnitude(x, y int) float64 {
+	i := y*g.Width + x
+	return math.Hypot(g.Dx[i], g.Dy[i])
+}
+
+// Direction renvoie la direction du gradient au pixel (x, y), en radians entre -π et π.
+// 0 correspond à une intensité croissante vers la droite, π/2 vers le bas.
+func (g *Gradient) Direction(x, y int) float64 {
+	i := y*g.Width + x
+	return math.Atan2(g.Dy[i], g.Dx[i])
+}
+
+// Gradient calcule le gradient de l'image avec l'opérateur donné. Les bords sont prolongés.
+func (pgm *PGM) Gradient(op GradientOperator) *Gradient {
+	kx, ky := op.kernels()
+	plane := pgm.plane()
+	return &Gradient{
+		Width:  pgm.width,
+		Height: pgm.height,
+		Dx:     raster.ConvolvePlane(plane, pgm.width, pgm.height, kx, EdgeReplicate, 0),
+		Dy:     raster.ConvolvePlane(plane, pgm.width, pgm.height, ky, EdgeReplicate, 0),
+	}
+}
+
+// EdgeMagnitude renvoie une image de la norme du gradient, bornée à la valeur maximale de l'image,
+// ainsi que le gradient lui-même pour exploiter sa direction.
+func (pgm *PGM) EdgeMagnitude(op GradientOperator) (*PGM, *Gradient) {
+	g := pgm.Gradient(op)
+	magnitude := make([]float64, len(g.Dx))
+	for i := range magnitude {
+		magnitude[i] = math.Hypot(g.Dx[i], g.Dy[i])
+	}
+	return pgm.fromPlane(magnitude), g
+}
+
+// Laplacian renvoie une image de la valeur absolue du laplacien, bornée à la valeur maximale de l'image.
+// Avec diagonals, les huit voisins sont pris en compte au lieu des quatre voisins directs.
+func (pgm *PGM) Laplacian(diagonals bool) *PGM {
+	k := NewKernel([][]float64{{0, 1, 0}, {1, -4, 1}, {0, 1, 0}})
+	if diagonals {
+		k = NewKernel([][]float64{{1, 1, 1}, {1, -8, 1}, {1, 1, 1}})
+	}
+	plane := raster.ConvolvePlane(pgm.plane(), pgm.width, pgm.height, k, EdgeReplicate, 0)
+	for i, v := range plane {
+		plane[i] = math.Abs(v)
+	}
+	return pgm.fromPlane(plane)
+}
+
+// fromPlane renvoie une nouvelle image de la taille de pgm dont les valeurs sont celles du plan, arrondies et bornées.
+func (pgm *PGM) fromPlane(plane []float64) *PGM {
+	result := &PGM{
+		data:        make([][]uint8, pgm.height),
+		width:       pgm.width,
+		height:      pgm.height,
+		magicNumber: pgm.magicNumber,
+		max:         pgm.max,
+	}
+	for y := range result.data {
+		result.data[y] = make([]uint8, pgm.width)
+	}
+	result.setPlane(plane, 0)
+	return result
+}
+
+// EdgeMap contient les contours détectés par Canny : Edges[y*Width+x] vaut true si le pixel (x, y)
+// est sur un contour.
+type EdgeMap struct {
+	Width, Height int
+	Edges         []bool
+}
+
+// At indique si le pixel (x, y) est sur un contour ; les pixels hors de l'image n'en font pas partie.
+func (m *EdgeMap) At(x, y int) bool {
+	return x >= 0 && x < m.Width && y >= 0 && y < m.Height && m.Edges[y*m.Width+x]
+}
+
+// Canny détecte les contours de l'image avec la méthode de Canny : lissage gaussien d'écart type sigma
+// (aucun si sigma vaut 0), gradient de Sobel, suppression des non-maxima puis seuillage par hystérésis.
+// Les pixels dont le gradient dépasse high sont des contours, ainsi que ceux dépassant low qui leur
+// sont reliés. Les seuils s'expriment dans les unités des valeurs de l'image.
+func (pgm *PGM) Canny(sigma, low, high float64) *EdgeMap {
+	if sigma < 0 || low < 0 || low > high {
+		fmt.Println("Les paramètres du détecteur de Canny ne sont pas valides.")
+		return nil
+	}
+	w, h := pgm.width, pgm.height
+	plane := pgm.plane()
+	if sigma > 0 {
+		plane = raster.ConvolvePlane(plane, w, h, GaussianKernel(sigma), EdgeReplicate, 0)
+	}
+	kx, ky := OperatorSobel.kernels()
+	dx := raster.ConvolvePlane(plane, w, h, kx, EdgeReplicate, 0)
+	dy := raster.ConvolvePlane(plane, w, h, ky, EdgeReplicate, 0)
+	magnitude := make([]float64, w*h)
+	for i := range magnitude {
+		magnitude[i] = math.Hypot(dx[i], dy[i])
+	}
+	at := func(x, y int) float64 {
+		if x < 0 || x >= w || y < 0 || y >= h {
+			return 0
+		}
+		return magnitude[y*w+x]
+	}
+
+	// Suppression des non-maxima : un pixel n'est gardé que s'il est un maximum local
+	// le long de la direction du gradient, arrondie à 45°.
+	const (
+		none = iota
+		weak
+		strong
+	)
+	state := make([]uint8, w*h)
+	var stack []int
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			i := y*w + x
+			m := magnitude[i]
+			if m < low || m == 0 {
+				continue
+			}
+			angle := math.Atan2(dy[i], dx[i]) * 180 / math.Pi
+			if angle < 0 {
+				angle += 180
+			}
+			var ox, oy int
+			switch {
+			case angle < 22.5 || angle >= 157.5:
+				ox, oy = 1, 0
+			case angle < 67.5:
+				ox, oy = 1, 1
+			case angle < 112.5:
+				ox, oy = 0, 1
+			default:
+				ox, oy = -1, 1
+			}
+			// L'inégalité stricte d'un côté garde un seul pixel sur les crêtes de deux pixels d'épaisseur
+			if m < at(x+ox, y+oy) || m <= at(x-ox, y-oy) {
+				continue
+			}
+			if m >= high {
+				state[i] = strong
+				stack = append(stack, i)
+			} else {
+				state[i] = weak
+			}
+		}
+	}
+
+	// Hystérésis : les pixels faibles reliés à un pixel fort deviennent forts
+	for len(stack) > 0 {
+		i := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		x, y := i%w, i/w
+		for ny := max(y-1, 0); ny <= min(y+1, h-1); ny++ {
+			for nx := max(x-1, 0); nx <= min(x+1, w-1); nx++ {
+				if j := ny*w + nx; state[j] == weak {
+					state[j] = strong
+					stack = append(stack, j)
+				}
+			}
+		}
+	}
+
+	edges := &EdgeMap{Width: w, Height: h, Edges: make([]bool, w*h)}
+	for i, v := range state {
+		edges.Edges[i] = v == strong
+	}
+	return edges
+}
@@ -0,0 +1,87 @@
+package Netpbm
+
+import (
+	"math"
+	"testing"
+)
+
+// stepPGM crée une image noire à gauche de la colonne 10 et de la valeur top (lignes 0 à 9)
+// ou bottom (lignes 10 à 19) à droite.
+func stepPGM(top, bottom uint8) *PGM {
+	pgm := newBlankPGM(20, 20)
+	for y, row := range pgm.data {
+		for x := 10; x < 20; x++ {
+			row[x] = top
+			if y >= 10 {
+				row[x] = bottom
+			}
+		}
+	}
+	return pgm
+}
+
+func TestPGMEdgeMagnitude(t *testing.T) {
+	for _, op := range []GradientOperator{OperatorSobel, OperatorPrewitt, OperatorScharr} {
+		magnitude, g := stepPGM(200, 200).EdgeMagnitude(op)
+		if magnitude.data[5][9] != 200 || magnitude.data[5][10] != 200 || magnitude.data[5][8] != 0 || magnitude.data[5][11] != 0 {
+			t.Fatalf("operator %d: magnitude wrong: %v", op, magnitude.data[5])
+		}
+		if g.Direction(9, 5) != 0 || math.Abs(g.Magnitude(9, 5)-200) > 1e-9 {
+			t.Fatalf("operator %d: direction %v, magnitude %v", op, g.Direction(9, 5), g.Magnitude(9, 5))
+		}
+	}
+
+	pgm := newBlankPGM(20, 20)
+	for y := 10; y < 20; y++ {
+		for x := range pgm.data[y] {
+			pgm.data[y][x] = 100
+		}
+	}
+	if g := pgm.Gradient(OperatorSobel); math.Abs(g.Direction(5, 10)-math.Pi/2) > 1e-9 {
+		t.Fatalf("Direction of a horizontal edge = %v", g.Direction(5, 10))
+	}
+}
+
+func TestPGMLaplacian(t *testing.T) {
+	laplacian := stepPGM(200, 200).Laplacian(false)
+	if laplacian.data[3][9] != 200 || laplacian.data[3][10] != 200 || laplacian.data[3][5] != 0 {
+		t.Fatalf("Laplacian wrong: %v", laplacian.data[3])
+	}
+	if stepPGM(100, 100).Laplacian(true).data[3][9] != 255 {
+		t.Fatal("8-neighbour Laplacian should be clamped to max")
+	}
+}
+
+func TestPGMCanny(t *testing.T) {
+	edges := stepPGM(200, 200).Canny(0, 50, 100)
+	count := 0
+	for y := 0; y < edges.Height; y++ {
+		for x := 0; x < edges.Width; x++ {
+			if edges.At(x, y) {
+				count++
+				if x != 9 {
+					t.Fatalf("Unexpected edge at (%d, %d)", x, y)
+				}
+			}
+		}
+	}
+	if count != 20 {
+		t.Fatalf("Canny should find a one pixel wide line, got %d pixels", count)
+	}
+
+	// Hystérésis : la partie faible n'est gardée que si elle est reliée à la partie forte
+	weak := stepPGM(60, 60).Canny(0, 40, 100)
+	connected := stepPGM(200, 60).Canny(0, 40, 100)
+	for y := 0; y < 20; y++ {
+		if weak.At(9, y) {
+			t.Fatalf("Weak isolated edge kept at row %d", y)
+		}
+		if !connected.At(9, y) && !connected.At(10, y) {
+			t.Fatalf("Weak edge connected to a strong one lost at row %d", y)
+		}
+	}
+
+	if blurred := stepPGM(200, 200).Canny(1.4, 20, 40); !blurred.At(9, 10) && !blurred.At(10, 10) {
+		t.Fatal("Canny with smoothing should still find the edge")
+	}
+}
@@ -0,0 +1,45 @@
+package Netpbm
+
+import "github.com/dada416-lebg/Netpbm/internal/raster"
+
+// Connectivity définit le voisinage utilisé pour relier les pixels entre eux.
+type Connectivity int
+
+const (
+	// Connect4 relie un pixel à ses voisins horizontaux et verticaux.
+	Connect4 Connectivity = 4
+	// Connect8 relie aussi un pixel à ses voisins en diagonale.
+	Connect8 Connectivity = 8
+)
+
+// FloodFill remplit avec value la zone connexe contenant p dont les pixels diffèrent de la valeur de p
+// d'au plus tolerance.
+// Un point de départ hors de l'image la laisse inchangée.
+func (pgm *PGM) FloodFill(p Point, value uint8, tolerance int, connectivity Connectivity) {
+	if p.X < 0 || p.X >= pgm.width || p.Y < 0 || p.Y >= pgm.height {
+		return
+	}
+	seed := int(pgm.data[p.Y][p.X])
+	raster.ScanlineFill(pgm.width, pgm.height, p.X, p.Y, connectivity == Connect8, func(x, y int) bool {
+		return absInt(int(pgm.data[y][x])-seed) <= tolerance
+	}, func(x, y int) {
+		pgm.data[y][x] = value
+	})
+}
+
+// BoundaryFill remplit avec value la zone connexe contenant p délimitée par les pixels de valeur boundary.
+// Un point de départ hors de l'image la laisse inchangée.
+func (pgm *PGM) BoundaryFill(p Point, value, boundary uint8, connectivity Connectivity) {
+	raster.ScanlineFill(pgm.width, pgm.height, p.X, p.Y, connectivity == Connect8, func(x, y int) bool {
+		return pgm.data[y][x] != boundary
+	}, func(x, y int) {
+		pgm.data[y][x] = value
+	})
+}
+
+func absInt(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
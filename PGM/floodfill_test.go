@@ -0,0 +1,57 @@
+package Netpbm
+
+import "testing"
+
+func TestFloodFillPGM(t *testing.T) {
+	pgm := newBlankPGM(8, 8)
+	for y := 0; y < 8; y++ {
+		pgm.data[y][4] = 100
+	}
+	pgm.data[3][4] = 3
+	pgm.FloodFill(Point{X: 0, Y: 0}, 50, 5, Connect4)
+
+	// La valeur 3 est dans la tolérance : le remplissage traverse la barrière
+	if pgm.data[0][7] != 50 || pgm.data[3][4] != 50 {
+		t.Error("Fill did not cross through the tolerant gap")
+	}
+	if pgm.data[0][4] != 100 {
+		t.Error("Barrier should not be filled")
+	}
+
+	pgm = newBlankPGM(8, 8)
+	for y := 0; y < 8; y++ {
+		pgm.data[y][4] = 100
+	}
+	pgm.FloodFill(Point{X: 0, Y: 0}, 50, 5, Connect8)
+	if pgm.data[0][7] != 0 || pgm.data[7][3] != 50 {
+		t.Error("Fill crossed a solid barrier")
+	}
+}
+
+func TestBoundaryFillPGM(t *testing.T) {
+	pgm := newBlankPGM(6, 6)
+	for i := 0; i < 6; i++ {
+		pgm.data[i][i] = 255
+	}
+	pgm.BoundaryFill(Point{X: 5, Y: 0}, 7, 255, Connect4)
+	count := 0
+	for y := 0; y < 6; y++ {
+		for x := 0; x < 6; x++ {
+			if pgm.data[y][x] == 7 {
+				count++
+			}
+		}
+	}
+	if count != 15 {
+		t.Errorf("Boundary fill wrong: %d", count)
+	}
+}
+
+func TestFloodFillOutOfBoundsPGM(t *testing.T) {
+	pgm := newBlankPGM(4, 4)
+	pgm.FloodFill(Point{X: -1, Y: 2}, 9, 0, Connect4)
+	pgm.BoundaryFill(Point{X: 4, Y: 0}, 9, 255, Connect8)
+	if countValue(pgm, 9) != 0 {
+		t.Error("Fill from a seed outside the image should do nothing")
+	}
+}
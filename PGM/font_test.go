@@ -0,0 +1,63 @@
+package Netpbm
+
+import (
+	"strings"
+	"testing"
+)
+
+// newBlankPGM crée une image noire en mémoire pour les tests.
+func newBlankPGM(width, height int) *PGM {
+	data := make([][]uint8, height)
+	for y := range data {
+		data[y] = make([]uint8, width)
+	}
+	return &PGM{data, width, height, "P2", 255}
+}
+
+func TestDrawTextPGM(t *testing.T) {
+	pgm := newBlankPGM(20, 12)
+	pgm.DrawText(Point{X: 1, Y: 2}, "T", 200)
+
+	for x := 1; x <= 5; x++ {
+		if pgm.data[2][x] != 200 {
+			t.Errorf("Pixel at (%d, 2) not drawn", x)
+		}
+	}
+	for y := 3; y <= 8; y++ {
+		if pgm.data[y][3] != 200 {
+			t.Errorf("Pixel at (3, %d) not drawn", y)
+		}
+	}
+}
+
+func TestDrawTextClippedPGM(t *testing.T) {
+	pgm := newBlankPGM(4, 4)
+	// Le texte qui dépasse de l'image est simplement coupé
+	pgm.DrawTextWithOptions(Point{X: -3, Y: -3}, "WW\nWW", 255, TextOptions{Scale: 3})
+}
+
+func TestMeasureTextPGM(t *testing.T) {
+	width, height := MeasureText("abc\nd", TextOptions{Scale: 2})
+	if width != 36 || height != 32 {
+		t.Errorf("Wrong text extents: %dx%d", width, height)
+	}
+}
+
+func TestParseBDFPGM(t *testing.T) {
+	data := "STARTFONT 2.1\nFONTBOUNDINGBOX 2 2 0 0\nSTARTCHAR x\nENCODING 120\nBBX 2 2 0 0\nBITMAP\n80\n40\nENDCHAR\nENDFONT\n"
+	font, err := ParseBDF(strings.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if font.Ascent != 2 || font.Descent != 0 {
+		t.Errorf("Metrics from bounding box wrong: %d %d", font.Ascent, font.Descent)
+	}
+	pgm := newBlankPGM(4, 2)
+	pgm.DrawTextWithOptions(Point{}, "xx", 9, TextOptions{Font: font})
+	want := []uint8{9, 0, 9, 0, 0, 9, 0, 9}
+	for i, v := range want {
+		if pgm.data[i/4][i%4] != v {
+			t.Errorf("Pixel at (%d, %d) wrong: wanted %d got %d", i%4, i/4, v, pgm.data[i/4][i%4])
+		}
+	}
+}
@@ -0,0 +1,256 @@
+package Netpbm
+
+// StructuringElement est l'élément structurant plat des opérations morphologiques, donné par les
+// décalages de ses pixels par rapport à son origine.
+type StructuringElement struct {
+	offsets []Point
+	// rectangle indique que l'élément est un rectangle plein, traité par l'algorithme de van Herk/Gil-Werman.
+	rectangle bool
+}
+
+// RectangleElement renvoie un rectangle plein de width × height pixels centré sur son origine.
+func RectangleElement(width, height int) StructuringElement {
+	se := StructuringElement{rectangle: width > 0 && height > 0}
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			se.offsets = append(se.offsets, Point{x - width/2, y - height/2})
+		}
+	}
+	return se
+}
+
+// SquareElement renvoie un carré plein de size × size pixels centré sur son origine.
+func SquareElement(size int) StructuringElement {
+	return RectangleElement(size, size)
+}
+
+// DiskElement renvoie le disque de rayon radius centré sur son origine.
+func DiskElement(radius int) StructuringElement {
+	var se StructuringElement
+	for y := -radius; y <= radius; y++ {
+		for x := -radius; x <= radius; x++ {
+			if x*x+y*y <= radius*radius {
+				se.offsets = append(se.offsets, Point{x, y})
+			}
+		}
+	}
+	return se
+}
+
+// CrossElement renvoie une croix dont les quatre branches s'étendent de radius pixels autour de son origine.
+func CrossElement(radius int) StructuringElement {
+	se := StructuringElement{offsets: []Point{{0, 0}}}
+	for i := 1; i <= radius; i++ {
+		se.offsets = append(se.offsets, Point{i, 0}, Point{-i, 0}, Point{0, i}, Point{0, -i})
+	}
+	return se
+}
+
+// ElementFromPattern renvoie l'élément formé des cases à true de pattern, rangées ligne par ligne,
+// d'origine la case origin du motif. L'origine n'a pas besoin de faire partie de l'élément.
+func ElementFromPattern(pattern [][]bool, origin Point) StructuringElement {
+	var se StructuringElement
+	for y, row := range pattern {
+		for x, set := range row {
+			if set {
+				se.offsets = append(se.offsets, Point{x - origin.X, y - origin.Y})
+			}
+		}
+	}
+	return se
+}
+
+// bounds renvoie les décalages extrêmes de l'élément.
+func (se StructuringElement) bounds() (minX, minY, maxX, maxY int) {
+	for i, o := range se.offsets {
+		if i == 0 {
+			minX, minY, maxX, maxY = o.X, o.Y, o.X, o.Y
+			continue
+		}
+		minX, minY = min(minX, o.X), min(minY, o.Y)
+		maxX, maxY = max(maxX, o.X), max(maxY, o.Y)
+	}
+	return
+}
+
+// Erode remplace chaque pixel par la plus petite valeur couverte par l'élément placé sur lui par son origine.
+// Les pixels hors de l'image sont ignorés.
+func (pgm *PGM) Erode(se StructuringElement) {
+	pgm.morphology(func(plane []uint8) []uint8 { return pgm.erodePlane(plane, se, false) })
+}
+
+// Dilate remplace chaque pixel par la plus grande valeur des pixels dont l'élément, placé sur eux
+// par son origine, le couvre. Les pixels hors de l'image sont ignorés.
+func (pgm *PGM) Dilate(se StructuringElement) {
+	pgm.morphology(func(plane []uint8) []uint8 { return pgm.erodePlane(plane, se, true) })
+}
+
+// Open érode puis dilate l'image : les détails clairs plus petits que l'élément disparaissent.
+func (pgm *PGM) Open(se StructuringElement) {
+	pgm.morphology(func(plane []uint8) []uint8 {
+		return pgm.erodePlane(pgm.erodePlane(plane, se, false), se, true)
+	})
+}
+
+// Close dilate puis érode l'image : les détails sombres plus petits que l'élément disparaissent.
+func (pgm *PGM) Close(se StructuringElement) {
+	pgm.morphology(func(plane []uint8) []uint8 {
+		return pgm.erodePlane(pgm.erodePlane(plane, se, true), se, false)
+	})
+}
+
+// MorphologicalGradient remplace chaque pixel par la différence entre la dilatation et l'érosion :
+// les contours ressortent en clair. Les différences négatives, possibles quand l'élément ne contient
+// pas son origine, sont ramenées à 0.
+func (pgm *PGM) MorphologicalGradient(se StructuringElement) {
+	pgm.morphology(func(plane []uint8) []uint8 {
+		dilated, eroded := pgm.erodePlane(plane, se, true), pgm.erodePlane(plane, se, false)
+		for i := range dilated {
+			dilated[i] = subtractSaturated(dilated[i], eroded[i])
+		}
+		return dilated
+	})
+}
+
+// TopHat soustrait à l'image son ouverture : il ne reste que les détails clairs plus petits que l'élément,
+// débarrassés d'un fond sombre irrégulier. Les différences négatives sont ramenées à 0.
+func (pgm *PGM) TopHat(se StructuringElement) {
+	pgm.morphology(func(plane []uint8) []uint8 {
+		opened := pgm.erodePlane(pgm.erodePlane(plane, se, false), se, true)
+		for i := range opened {
+			opened[i] = subtractSaturated(plane[i], opened[i])
+		}
+		return opened
+	})
+}
+
+// BlackTopHat soustrait l'image à sa fermeture : il ne reste que les détails sombres plus petits
+// que l'élément, comme le texte d'un document mal éclairé, débarrassés du fond clair irrégulier.
+// Les différences négatives sont ramenées à 0.
+func (pgm *PGM) BlackTopHat(se StructuringElement) {
+	pgm.morphology(func(plane []uint8) []uint8 {
+		closed := pgm.erodePlane(pgm.erodePlane(plane, se, true), se, false)
+		for i := range closed {
+			closed[i] = subtractSaturated(closed[i], plane[i])
+		}
+		return closed
+	})
+}
+
+// subtractSaturated renvoie a - b, ou 0 si b est plus grand que a.
+func subtractSaturated(a, b uint8) uint8 {
+	if b > a {
+		return 0
+	}
+	return a - b
+}
+
+// morphology remplace les valeurs de l'image par le résultat de op appliqué à ses valeurs rangées ligne par ligne.
+func (pgm *PGM) morphology(op func(plane []uint8) []uint8) {
+	if pgm.width == 0 || pgm.height == 0 {
+		return
+	}
+	plane := make([]uint8, 0, pgm.width*pgm.height)
+	for _, row := range pgm.data[:pgm.height] {
+		plane = append(plane, row[:pgm.width]...)
+	}
+	plane = op(plane)
+	for y := 0; y < pgm.height; y++ {
+		copy(pgm.data[y], plane[y*pgm.width:(y+1)*pgm.width])
+	}
+}
+
+// erodePlane renvoie l'érosion, ou la dilatation si dilate vaut true, d'un plan de la taille de l'image.
+func (pgm *PGM) erodePlane(src []uint8, se StructuringElement, dilate bool) []uint8 {
+	w, h := pgm.width, pgm.height
+	pick := func(a, b uint8) uint8 { return min(a, b) }
+	pad := uint8(255)
+	if pgm.max > 0 && pgm.max < 255 {
+		pad = uint8(pgm.max)
+	}
+	if dilate {
+		pick = func(a, b uint8) uint8 { return max(a, b) }
+		pad = 0
+	}
+	if len(se.offsets) == 0 {
+		return append([]uint8(nil), src...)
+	}
+
+	if se.rectangle {
+		// Fenêtre de la dilatation : l'élément réfléchi
+		minX, minY, maxX, maxY := se.bounds()
+		startX, startY := minX, minY
+		if dilate {
+			startX, startY = -maxX, -maxY
+		}
+		sizeX, sizeY := maxX-minX+1, maxY-minY+1
+		tmp := make([]uint8, w*h)
+		line := make([]uint8, max(w, h))
+		for y := 0; y < h; y++ {
+			copy(tmp[y*w:], vanHerk(src[y*w:(y+1)*w], sizeX, startX, pad, pick))
+		}
+		dst := make([]uint8, w*h)
+		for x := 0; x < w; x++ {
+			for y := 0; y < h; y++ {
+				line[y] = tmp[y*w+x]
+			}
+			for y, v := range vanHerk(line[:h], sizeY, startY, pad, pick) {
+				dst[y*w+x] = v
+			}
+		}
+		return dst
+	}
+
+	dst := make([]uint8, w*h)
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			v := pad
+			for _, o := range se.offsets {
+				if dilate {
+					o = Point{-o.X, -o.Y}
+				}
+				sx, sy := x+o.X, y+o.Y
+				if sx >= 0 && sx < w && sy >= 0 && sy < h {
+					v = pick(v, src[sy*w+sx])
+				}
+			}
+			dst[y*w+x] = v
+		}
+	}
+	return dst
+}
+
+// vanHerk renvoie, pour chaque indice i, pick appliqué aux valeurs src[i+start] à src[i+start+size-1],
+// les valeurs hors du tableau valant pad. L'algorithme de van Herk/Gil-Werman découpe le tableau en blocs
+// de size valeurs et combine un cumul depuis le début et un cumul depuis la fin de chaque bloc :
+// son coût ne dépend pas de size.
+func vanHerk(src []uint8, size, start int, pad uint8, pick func(a, b uint8) uint8) []uint8 {
+	n := len(src)
+	m := n + size - 1
+	at := func(q int) uint8 {
+		if i := q + start; i >= 0 && i < n {
+			return src[i]
+		}
+		return pad
+	}
+	forward, backward := make([]uint8, m), make([]uint8, m)
+	for q := 0; q < m; q++ {
+		if q%size == 0 {
+			forward[q] = at(q)
+		} else {
+			forward[q] = pick(forward[q-1], at(q))
+		}
+	}
+	for q := m - 1; q >= 0; q-- {
+		if q == m-1 || (q+1)%size == 0 {
+			backward[q] = at(q)
+		} else {
+			backward[q] = pick(backward[q+1], at(q))
+		}
+	}
+	dst := make([]uint8, n)
+	for i := range dst {
+		dst[i] = pick(backward[i], forward[i+size-1])
+	}
+	return dst
+}
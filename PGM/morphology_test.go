@@ -0,0 +1,99 @@
+package Netpbm
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestPGMMorphologyRectangleMatchesGeneric(t *testing.T) {
+	rng := rand.New(rand.NewSource(4))
+	for _, size := range [][2]int{{1, 1}, {3, 3}, {4, 2}, {7, 5}, {30, 1}} {
+		fast := RectangleElement(size[0], size[1])
+		generic := StructuringElement{offsets: fast.offsets}
+		for _, dilate := range []bool{false, true} {
+			pgm := newBlankPGM(23, 17)
+			for _, row := range pgm.data {
+				for x := range row {
+					row[x] = uint8(rng.Intn(256))
+				}
+			}
+			plane := make([]uint8, 0, 23*17)
+			for _, row := range pgm.data {
+				plane = append(plane, row...)
+			}
+			want := pgm.erodePlane(plane, generic, dilate)
+			got := pgm.erodePlane(plane, fast, dilate)
+			for i := range want {
+				if got[i] != want[i] {
+					t.Fatalf("size %v, dilate %v: pixel %d = %d, want %d", size, dilate, i, got[i], want[i])
+				}
+			}
+		}
+	}
+}
+
+func TestPGMMorphology(t *testing.T) {
+	pgm := newBlankPGM(12, 12)
+	pgm.data[5][5] = 200
+	pgm.Dilate(CrossElement(1))
+	if countValue(pgm, 200) != 5 || pgm.data[4][5] != 200 || pgm.data[4][4] != 0 {
+		t.Fatalf("Dilate with a cross wrong: %v", pgm.data)
+	}
+	pgm.Erode(CrossElement(1))
+	if countValue(pgm, 200) != 1 || pgm.data[5][5] != 200 {
+		t.Fatalf("Erode should undo the dilation: %v", pgm.data)
+	}
+
+	pgm.Open(SquareElement(3))
+	if countValue(pgm, 0) != 144 {
+		t.Fatal("Open should remove a bright pixel")
+	}
+
+	pgm.DrawFilledRectangle(Point{2, 2}, 6, 6, 100)
+	pgm.MorphologicalGradient(SquareElement(3))
+	if pgm.data[4][4] != 0 || pgm.data[2][2] != 100 || pgm.data[1][1] != 100 || pgm.data[0][0] != 0 {
+		t.Fatalf("Gradient should highlight the border of the square: %v", pgm.data)
+	}
+}
+
+func TestPGMBlackTopHatUnevenBackground(t *testing.T) {
+	// Fond clair qui s'assombrit vers la droite, avec un trait sombre de 2 pixels
+	pgm := newBlankPGM(30, 10)
+	for _, row := range pgm.data {
+		for x := range row {
+			row[x] = uint8(230 - 3*x)
+		}
+		row[10], row[11] = 50, 50
+	}
+	pgm.BlackTopHat(RectangleElement(7, 1))
+	for y := range pgm.data {
+		if pgm.data[y][10] < 120 || pgm.data[y][11] < 120 {
+			t.Fatalf("BlackTopHat should bring out the stroke: %v", pgm.data[y])
+		}
+		if pgm.data[y][3] > 10 || pgm.data[y][25] > 10 {
+			t.Fatalf("BlackTopHat should remove the background: %v", pgm.data[y])
+		}
+	}
+}
+
+func TestPGMMorphologyElementWithoutOrigin(t *testing.T) {
+	// L'élément ne couvre que le voisin de droite : la dilatation peut être plus petite que l'érosion
+	se := ElementFromPattern([][]bool{{false, true}}, Point{0, 0})
+	newRow := func() *PGM {
+		pgm := newBlankPGM(4, 1)
+		copy(pgm.data[0], []uint8{0, 10, 255, 255})
+		return pgm
+	}
+	for name, op := range map[string]func(*PGM){
+		"MorphologicalGradient": func(pgm *PGM) { pgm.MorphologicalGradient(se) },
+		"TopHat":                func(pgm *PGM) { pgm.TopHat(se) },
+		"BlackTopHat":           func(pgm *PGM) { pgm.BlackTopHat(se) },
+	} {
+		pgm := newRow()
+		op(pgm)
+		// Toutes les différences sont négatives ou nulles et doivent être ramenées à 0
+		if countValue(pgm, 0) != 4 {
+			t.Errorf("%s wrapped around: %v", name, pgm.data[0])
+		}
+	}
+}
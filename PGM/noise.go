@@ -0,0 +1,52 @@
+package Netpbm
+
+import (
+	"math"
+
+	"github.com/dada416-lebg/Netpbm/internal/noise"
+)
+
+// NoiseType choisit la fonction de bruit de base.
+type NoiseType = noise.Type
+
+const (
+	// NoisePerlin est le bruit de gradient de Perlin.
+	NoisePerlin = noise.Perlin
+	// NoiseValue interpole des valeurs aléatoires placées sur une grille.
+	NoiseValue = noise.Value
+	// NoiseWorley (ou bruit cellulaire) est la distance au point caractéristique le plus proche.
+	NoiseWorley = noise.Worley
+)
+
+// NoiseOptions regroupe les paramètres d'un générateur de bruit fractal.
+type NoiseOptions = noise.Options
+
+// Noise est un générateur de bruit déterministe.
+type Noise = noise.Noise
+
+// DefaultNoiseOptions renvoie des paramètres par défaut : bruit de Perlin d'une octave, graine 42, cellules de 50 pixels.
+func DefaultNoiseOptions() NoiseOptions {
+	return noise.DefaultOptions()
+}
+
+// NewNoise crée un générateur de bruit. Les paramètres nuls ou négatifs prennent la valeur par défaut.
+func NewNoise(options NoiseOptions) *Noise {
+	return noise.New(options)
+}
+
+// DrawNoise remplit l'image avec un bruit dont les valeurs vont de 0 à la valeur maximale de l'image.
+func (pgm *PGM) DrawNoise(options NoiseOptions) {
+	generator := NewNoise(options)
+	for y := 0; y < pgm.height; y++ {
+		for x := 0; x < pgm.width; x++ {
+			pgm.data[y][x] = uint8(math.Round(generator.At(float64(x), float64(y)) * float64(pgm.max)))
+		}
+	}
+}
+
+// NewHeightmap crée une carte de hauteurs PGM (P2, valeur maximale 255) à partir d'un bruit.
+func NewHeightmap(width, height int, options NoiseOptions) *PGM {
+	pgm := NewPGM(width, height)
+	pgm.DrawNoise(options)
+	return pgm
+}
@@ -0,0 +1,39 @@
+package Netpbm
+
+import "testing"
+
+func TestNewHeightmap(t *testing.T) {
+	options := DefaultNoiseOptions()
+	options.Octaves = 5
+	options.Scale = 16
+	a := NewHeightmap(64, 64, options)
+	b := NewHeightmap(64, 64, options)
+	if a.width != 64 || a.height != 64 || a.max != 255 {
+		t.Fatal("Heightmap size wrong")
+	}
+	low, high := uint8(255), uint8(0)
+	for y := range a.data {
+		for x := range a.data[y] {
+			if a.data[y][x] != b.data[y][x] {
+				t.Fatal("Heightmap is not deterministic")
+			}
+			low, high = min(low, a.data[y][x]), max(high, a.data[y][x])
+		}
+	}
+	if low > 90 || high < 165 {
+		t.Errorf("Heightmap poorly spread: [%d, %d]", low, high)
+	}
+}
+
+func TestPGMDrawNoiseMax(t *testing.T) {
+	pgm := newBlankPGM(32, 32)
+	pgm.max = 15
+	pgm.DrawNoise(NoiseOptions{Type: NoiseWorley, Scale: 4})
+	for y := range pgm.data {
+		for x := range pgm.data[y] {
+			if pgm.data[y][x] > 15 {
+				t.Fatalf("Value %d exceeds max", pgm.data[y][x])
+			}
+		}
+	}
+}
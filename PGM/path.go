@@ -0,0 +1,70 @@
+package Netpbm
+
+import "github.com/dada416-lebg/Netpbm/internal/raster"
+
+// PointF représente un point en coordonnées flottantes.
+type PointF = raster.PointF
+
+// FillRule définit la règle utilisée pour décider si un point est à l'intérieur d'un chemin.
+type FillRule = raster.FillRule
+
+const (
+	// FillNonZero remplit les zones dont l'indice d'enroulement est non nul.
+	FillNonZero = raster.FillNonZero
+	// FillEvenOdd remplit les zones traversées un nombre impair de fois.
+	FillEvenOdd = raster.FillEvenOdd
+)
+
+// Path représente un chemin vectoriel composé d'un ou plusieurs sous-chemins. La construction
+// et l'aplatissement des courbes sont communs aux trois formats.
+type Path struct {
+	raster.Path
+}
+
+// NewPath crée un chemin vide.
+func NewPath() *Path {
+	return &Path{*raster.NewPath()}
+}
+
+// StrokePath trace le contour du chemin avec des lignes d'un pixel.
+func (pgm *PGM) StrokePath(path *Path, value uint8) {
+	for _, polyline := range path.Polylines() {
+		if len(polyline) == 1 {
+			pgm.plot(raster.Round(polyline[0].X), raster.Round(polyline[0].Y), value)
+			continue
+		}
+		for i := 0; i+1 < len(polyline); i++ {
+			pgm.DrawLine(roundPoint(polyline[i]), roundPoint(polyline[i+1]), value)
+		}
+	}
+}
+
+// StrokePathWidth trace le contour du chemin avec un trait d'épaisseur width et des jointures arrondies.
+func (pgm *PGM) StrokePathWidth(path *Path, width float64, value uint8) {
+	if width <= 1 {
+		pgm.StrokePath(path, value)
+		return
+	}
+	polygons := raster.StrokePolygons(&path.Path, width, path.EffectiveTolerance())
+	raster.FillPolygons(polygons, FillNonZero, pgm.width, pgm.height, pgm.fillSpan(value))
+}
+
+// FillPath remplit l'intérieur du chemin selon sa règle de remplissage.
+// Les sous-chemins ouverts sont implicitement fermés.
+func (pgm *PGM) FillPath(path *Path, value uint8) {
+	raster.FillPolygons(path.Polylines(), path.FillRule, pgm.width, pgm.height, pgm.fillSpan(value))
+}
+
+// fillSpan renvoie une fonction qui remplit les pixels [x0, x1) de la ligne y.
+func (pgm *PGM) fillSpan(value uint8) func(y, x0, x1 int) {
+	return func(y, x0, x1 int) {
+		for x := x0; x < x1; x++ {
+			pgm.data[y][x] = value
+		}
+	}
+}
+
+// roundPoint arrondit un point flottant au pixel le plus proche.
+func roundPoint(p PointF) Point {
+	return Point{raster.Round(p.X), raster.Round(p.Y)}
+}
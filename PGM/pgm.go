@@ -16,6 +16,11 @@ type PGM struct {
 	max         int
 }
 
+// Point représente la position d'un pixel.
+type Point struct {
+	X, Y int
+}
+
 // PBM représente une image PBM (Portable Bitmap).
 type PBM struct {
 	width  int
@@ -23,6 +28,21 @@ type PBM struct {
 	data   [][]bool
 }
 
+// NewPGM crée une image PGM noire de width × height pixels, au format P2 avec une valeur maximale de 255.
+func NewPGM(width, height int) *PGM {
+	pgm := &PGM{
+		data:        make([][]uint8, height),
+		width:       width,
+		height:      height,
+		magicNumber: "P2",
+		max:         255,
+	}
+	for y := range pgm.data {
+		pgm.data[y] = make([]uint8, width)
+	}
+	return pgm
+}
+
 // ReadPGM lit une image PGM à partir d'un fichier et renvoie une structure représentant l'image.
 func ReadPGM(filename string) (*PGM, error) {
 	file, err := os.Open(filename)
@@ -118,6 +138,13 @@ func (pgm *PGM) Set(x, y int, value uint8) {
 	pgm.data[y][x] = value
 }
 
+// plot définit la valeur du pixel (x, y) s'il se trouve dans l'image, sans message d'erreur.
+func (pgm *PGM) plot(x, y int, value uint8) {
+	if x >= 0 && x < pgm.width && y >= 0 && y < pgm.height {
+		pgm.data[y][x] = value
+	}
+}
+
 // Save enregistre l'image PGM dans un fichier et renvoie une erreur en cas de problème.
 func (pgm *PGM) Save(filename string) error {
 	file, err := os.Create(filename)
@@ -0,0 +1,45 @@
+package Netpbm
+
+import (
+	"fmt"
+
+	"github.com/dada416-lebg/Netpbm/internal/raster"
+)
+
+// MedianFilter remplace chaque pixel par la médiane du carré de côté 2 × radius + 1 qui l'entoure.
+// C'est le filtre de référence contre le bruit « poivre et sel ».
+func (pgm *PGM) MedianFilter(radius int) {
+	pgm.RankFilter(radius, 50)
+}
+
+// MinFilter remplace chaque pixel par la plus petite valeur du carré de côté 2 × radius + 1 qui l'entoure.
+func (pgm *PGM) MinFilter(radius int) {
+	pgm.RankFilter(radius, 0)
+}
+
+// MaxFilter remplace chaque pixel par la plus grande valeur du carré de côté 2 × radius + 1 qui l'entoure.
+func (pgm *PGM) MaxFilter(radius int) {
+	pgm.RankFilter(radius, 100)
+}
+
+// RankFilter remplace chaque pixel par le centile percentile, entre 0 et 100, des valeurs du carré
+// de côté 2 × radius + 1 qui l'entoure. Les bords sont prolongés. Le calcul utilise des histogrammes
+// glissants : son coût ne dépend pas du rayon.
+func (pgm *PGM) RankFilter(radius int, percentile float64) {
+	if radius <= 0 {
+		fmt.Println("Le rayon du filtre doit être positif.")
+		return
+	}
+	if percentile < 0 || percentile > 100 {
+		fmt.Println("Le centile doit être compris entre 0 et 100.")
+		return
+	}
+	plane := make([]uint8, 0, pgm.width*pgm.height)
+	for _, row := range pgm.data[:pgm.height] {
+		plane = append(plane, row[:pgm.width]...)
+	}
+	plane = raster.RankPlane(plane, pgm.width, pgm.height, radius, percentile)
+	for y := 0; y < pgm.height; y++ {
+		copy(pgm.data[y], plane[y*pgm.width:(y+1)*pgm.width])
+	}
+}
@@ -0,0 +1,77 @@
+package Netpbm
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+// bruteRank calcule le filtre de rang en triant chaque fenêtre.
+func bruteRank(pgm *PGM, radius int, percentile float64) [][]uint8 {
+	size := 2*radius + 1
+	rank := int(percentile/100*float64(size*size-1) + 0.5)
+	result := make([][]uint8, pgm.height)
+	for y := range result {
+		result[y] = make([]uint8, pgm.width)
+		for x := range result[y] {
+			var window []int
+			for j := -radius; j <= radius; j++ {
+				for i := -radius; i <= radius; i++ {
+					sx := min(max(x+i, 0), pgm.width-1)
+					sy := min(max(y+j, 0), pgm.height-1)
+					window = append(window, int(pgm.data[sy][sx]))
+				}
+			}
+			sort.Ints(window)
+			result[y][x] = uint8(window[rank])
+		}
+	}
+	return result
+}
+
+func TestPGMRankFilterMatchesSort(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for _, radius := range []int{1, 2, 4} {
+		for _, percentile := range []float64{0, 30, 50, 100} {
+			pgm := newBlankPGM(13, 9)
+			for _, row := range pgm.data {
+				for x := range row {
+					row[x] = uint8(rng.Intn(256))
+				}
+			}
+			want := bruteRank(pgm, radius, percentile)
+			pgm.RankFilter(radius, percentile)
+			for y := range want {
+				for x := range want[y] {
+					if pgm.data[y][x] != want[y][x] {
+						t.Fatalf("radius %d, percentile %v: (%d, %d) = %d, want %d", radius, percentile, x, y, pgm.data[y][x], want[y][x])
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestPGMMedianFilterSaltAndPepper(t *testing.T) {
+	pgm := newBlankPGM(10, 10)
+	for _, row := range pgm.data {
+		for x := range row {
+			row[x] = 120
+		}
+	}
+	pgm.data[2][3], pgm.data[6][7], pgm.data[8][1] = 255, 0, 255
+	pgm.MedianFilter(1)
+	if countValue(pgm, 120) != 100 {
+		t.Fatalf("MedianFilter should remove isolated noise: %v", pgm.data)
+	}
+
+	pgm.data[5][5] = 200
+	pgm.MaxFilter(1)
+	if countValue(pgm, 200) != 9 {
+		t.Fatalf("MaxFilter should grow the bright pixel to 3×3: %v", pgm.data)
+	}
+	pgm.MinFilter(1)
+	if countValue(pgm, 200) != 1 {
+		t.Fatalf("MinFilter should shrink it back: %v", pgm.data)
+	}
+}
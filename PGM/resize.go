@@ -0,0 +1,75 @@
+package Netpbm
+
+import (
+	"fmt"
+
+	"github.com/dada416-lebg/Netpbm/internal/raster"
+)
+
+// ResizeFilter est le filtre de reconstruction utilisé pour rééchantillonner une image.
+type ResizeFilter = raster.ResizeFilter
+
+const (
+	// FilterBox fait la moyenne des pixels couverts ; en réduction, c'est une moyenne par surface.
+	FilterBox = raster.FilterBox
+	// FilterBilinear est le filtre triangle, qui interpole linéairement entre deux pixels voisins.
+	FilterBilinear = raster.FilterBilinear
+	// FilterBicubic est la spline cubique de Catmull-Rom (B = 0, C = 0.5).
+	FilterBicubic = raster.FilterBicubic
+	// FilterMitchell est le filtre cubique de Mitchell-Netravali (B = C = 1/3).
+	FilterMitchell = raster.FilterMitchell
+	// FilterLanczos est le filtre de Lanczos à trois lobes.
+	FilterLanczos = raster.FilterLanczos
+)
+
+// Resize redimensionne l'image à width × height pixels avec le filtre donné.
+// Les niveaux de gris sont considérés comme codés en sRGB et le calcul est fait en lumière linéaire,
+// ce qui évite d'assombrir les détails fins lors d'une réduction.
+func (pgm *PGM) Resize(width, height int, filter ResizeFilter) {
+	if width <= 0 || height <= 0 {
+		fmt.Println("Les dimensions de l'image redimensionnée ne sont pas valides.")
+		return
+	}
+	maxValue := pgm.max
+	if maxValue <= 0 {
+		maxValue = 255
+	}
+	toLinear := raster.LinearTable(maxValue)
+
+	// Passe horizontale : hauteur d'origine × nouvelle largeur
+	horizontal := raster.Contributions(pgm.width, width, filter)
+	rows := make([][]float64, pgm.height)
+	for y := range rows {
+		rows[y] = make([]float64, width)
+		src := pgm.data[y]
+		for x, c := range horizontal {
+			rows[y][x] = c.Resample(func(i int) float64 { return toLinear[src[i]] }, pgm.width)
+		}
+	}
+
+	// Passe verticale et retour au codage sRGB
+	vertical := raster.Contributions(pgm.height, height, filter)
+	data := make([][]uint8, height)
+	for y, c := range vertical {
+		data[y] = make([]uint8, width)
+		for x := 0; x < width; x++ {
+			v := c.Resample(func(i int) float64 { return rows[i][x] }, pgm.height)
+			data[y][x] = toUint8(raster.LinearToSRGB(v) * float64(maxValue))
+		}
+	}
+
+	pgm.data = data
+	pgm.width = width
+	pgm.height = height
+}
+
+// toUint8 arrondit v et le ramène dans l'intervalle [0, 255].
+func toUint8(v float64) uint8 {
+	if v <= 0 {
+		return 0
+	}
+	if v >= 255 {
+		return 255
+	}
+	return uint8(v + 0.5)
+}
@@ -0,0 +1,27 @@
+package Netpbm
+
+import "testing"
+
+func TestPGMResize(t *testing.T) {
+	pgm := newBlankPGM(6, 6)
+	for y := 0; y < 6; y++ {
+		for x := 0; x < 6; x++ {
+			if (x+y)%2 == 0 {
+				pgm.data[y][x] = 255
+			}
+		}
+	}
+	pgm.Resize(3, 3, FilterBox)
+	if pgm.width != 3 || pgm.height != 3 || countValue(pgm, 188) != 9 {
+		t.Errorf("Checkerboard average wrong: %v", pgm.data)
+	}
+
+	for _, filter := range []ResizeFilter{FilterBilinear, FilterBicubic, FilterMitchell, FilterLanczos} {
+		flat := newBlankPGM(7, 5)
+		flat.DrawFilledRectangle(Point{0, 0}, 7, 5, 77)
+		flat.Resize(15, 2, filter)
+		if countValue(flat, 77) != 30 {
+			t.Errorf("Filter %d changed a uniform image", filter)
+		}
+	}
+}
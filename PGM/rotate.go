@@ -0,0 +1,135 @@
+package Netpbm
+
+import (
+	"math"
+
+	"github.com/dada416-lebg/Netpbm/internal/raster"
+)
+
+// remap remplace l'image par une image width × height dont le pixel (x, y) est le pixel source(x, y) de l'image d'origine.
+func (pgm *PGM) remap(width, height int, source func(x, y int) (int, int)) {
+	data := make([][]uint8, height)
+	for y := range data {
+		data[y] = make([]uint8, width)
+		for x := range data[y] {
+			sx, sy := source(x, y)
+			data[y][x] = pgm.data[sy][sx]
+		}
+	}
+	pgm.data = data
+	pgm.width, pgm.height = width, height
+}
+
+// Rotate90CCW fait pivoter l'image de 90° dans le sens inverse des aiguilles d'une montre.
+func (pgm *PGM) Rotate90CCW() {
+	w := pgm.width
+	pgm.remap(pgm.height, pgm.width, func(x, y int) (int, int) {
+		return w - 1 - y, x
+	})
+}
+
+// Rotate180 fait pivoter l'image de 180°.
+func (pgm *PGM) Rotate180() {
+	w, h := pgm.width, pgm.height
+	pgm.remap(w, h, func(x, y int) (int, int) {
+		return w - 1 - x, h - 1 - y
+	})
+}
+
+// Rotate270CW fait pivoter l'image de 270° dans le sens des aiguilles d'une montre, comme Rotate90CCW.
+func (pgm *PGM) Rotate270CW() {
+	pgm.Rotate90CCW()
+}
+
+// Transpose échange les lignes et les colonnes : l'image est retournée selon sa diagonale principale.
+func (pgm *PGM) Transpose() {
+	pgm.remap(pgm.height, pgm.width, func(x, y int) (int, int) {
+		return y, x
+	})
+}
+
+// Transverse retourne l'image selon son antidiagonale, qui va du coin supérieur droit au coin inférieur gauche.
+func (pgm *PGM) Transverse() {
+	w, h := pgm.width, pgm.height
+	pgm.remap(h, w, func(x, y int) (int, int) {
+		return w - 1 - y, h - 1 - x
+	})
+}
+
+// RotateOptions regroupe les paramètres d'une rotation d'angle quelconque.
+type RotateOptions struct {
+	// Filter est le filtre d'interpolation ; FilterBox revient au plus proche voisin.
+	Filter ResizeFilter
+	// Expand agrandit l'image pour contenir toute l'image tournée ; sinon la taille est conservée
+	// et les coins qui dépassent sont coupés.
+	Expand bool
+	// Background est le niveau de gris des zones qui ne proviennent pas de l'image d'origine.
+	Background uint8
+}
+
+// Rotate fait pivoter l'image de angle degrés dans le sens des aiguilles d'une montre autour de son centre.
+// Les multiples de 90° sont traités sans perte quand la taille de l'image le permet.
+func (pgm *PGM) Rotate(angle float64, options RotateOptions) {
+	angle = math.Mod(angle, 360)
+	if angle < 0 {
+		angle += 360
+	}
+	switch {
+	case angle == 0:
+		return
+	case angle == 180:
+		pgm.Rotate180()
+		return
+	case (angle == 90 || angle == 270) && (options.Expand || pgm.width == pgm.height):
+		if angle == 90 {
+			pgm.Rotate90CW()
+		} else {
+			pgm.Rotate90CCW()
+		}
+		return
+	}
+
+	rad := angle * math.Pi / 180
+	cos, sin := math.Cos(rad), math.Sin(rad)
+	width, height := pgm.width, pgm.height
+	if options.Expand {
+		width, height = raster.RotatedSize(pgm.width, pgm.height, cos, sin)
+	}
+
+	maxValue := pgm.max
+	if maxValue <= 0 {
+		maxValue = 255
+	}
+	toLinear := raster.LinearTable(maxValue)
+
+	srcCX, srcCY := float64(pgm.width)/2, float64(pgm.height)/2
+	dstCX, dstCY := float64(width)/2, float64(height)/2
+	data := make([][]uint8, height)
+	for y := range data {
+		data[y] = make([]uint8, width)
+		for x := range data[y] {
+			// Rotation inverse du centre du pixel, ramenée aux indices de la source
+			dx, dy := float64(x)+0.5-dstCX, float64(y)+0.5-dstCY
+			u := cos*dx + sin*dy + srcCX - 0.5
+			v := -sin*dx + cos*dy + srcCY - 0.5
+
+			sum, total := 0.0, 0.0
+			options.Filter.Sample(u, v, func(i, j int, w float64) {
+				sample := toLinear[options.Background]
+				if i >= 0 && i < pgm.width && j >= 0 && j < pgm.height {
+					sample = toLinear[pgm.data[j][i]]
+				}
+				sum += w * sample
+				total += w
+			})
+			if total == 0 {
+				data[y][x] = options.Background
+				continue
+			}
+			data[y][x] = toUint8(raster.LinearToSRGB(sum/total) * float64(maxValue))
+		}
+	}
+
+	pgm.data = data
+	pgm.width, pgm.height = width, height
+}
@@ -0,0 +1,39 @@
+package Netpbm
+
+import "testing"
+
+func TestPGMOrthogonalTransforms(t *testing.T) {
+	pgm := newBlankPGM(3, 2)
+	pgm.data = [][]uint8{{0, 1, 2}, {10, 11, 12}}
+	pgm.Rotate90CCW()
+	pgm.Transverse()
+	// Rotation de 90° dans le sens inverse puis retournement selon l'antidiagonale : symétrie verticale
+	want := [][]uint8{{10, 11, 12}, {0, 1, 2}}
+	if pgm.width != 3 || pgm.height != 2 {
+		t.Fatalf("Wrong size %dx%d", pgm.width, pgm.height)
+	}
+	for y := range want {
+		for x := range want[y] {
+			if pgm.data[y][x] != want[y][x] {
+				t.Fatalf("Wanted %v got %v", want, pgm.data)
+			}
+		}
+	}
+	pgm.Rotate180()
+	pgm.Transpose()
+	if pgm.data[0][0] != 2 || pgm.data[2][1] != 10 {
+		t.Errorf("Rotate180 then Transpose wrong: %v", pgm.data)
+	}
+}
+
+func TestPGMRotate(t *testing.T) {
+	pgm := newBlankPGM(21, 21)
+	pgm.DrawFilledRectangle(Point{0, 0}, 21, 21, 200)
+	pgm.Rotate(45, RotateOptions{Filter: FilterLanczos, Expand: true, Background: 0})
+	if pgm.width != 30 || pgm.height != 30 {
+		t.Fatalf("Expanded size wrong: %dx%d", pgm.width, pgm.height)
+	}
+	if pgm.data[15][15] != 200 || pgm.data[0][0] != 0 || pgm.data[15][1] == 0 {
+		t.Errorf("Rotated square wrong: %d %d %d", pgm.data[15][15], pgm.data[0][0], pgm.data[15][1])
+	}
+}
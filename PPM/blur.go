@@ -0,0 +1,54 @@
+package Netpbm
+
+import (
+	"fmt"
+
+	"github.com/dada416-lebg/Netpbm/internal/raster"
+)
+
+// GaussianKernel renvoie le noyau gaussien séparable d'écart type sigma, de rayon ceil(3 × sigma)
+// et de somme 1.
+func GaussianKernel(sigma float64) Kernel {
+	return raster.GaussianKernel(sigma)
+}
+
+// GaussianBlur applique un flou gaussien d'écart type sigma, en pixels, à chaque composante.
+// Les bords sont prolongés.
+func (ppm *PPM) GaussianBlur(sigma float64) {
+	if sigma <= 0 {
+		fmt.Println("L'écart type du flou doit être positif.")
+		return
+	}
+	ppm.Convolve(GaussianKernel(sigma), ConvolveOptions{Edge: EdgeReplicate})
+}
+
+// BoxBlur remplace chaque pixel par la moyenne du carré de côté 2 × radius + 1 qui l'entoure.
+// Le calcul utilise une somme glissante : son coût ne dépend pas du rayon. Les bords sont prolongés.
+func (ppm *PPM) BoxBlur(radius int) {
+	if radius <= 0 {
+		fmt.Println("Le rayon du flou doit être positif.")
+		return
+	}
+	planes := ppm.planes()
+	for c := range planes {
+		planes[c] = raster.BoxBlurPlane(planes[c], ppm.width, ppm.height, radius)
+	}
+	ppm.setPlanes(planes, 0)
+}
+
+// UnsharpMask accentue les détails en ajoutant amount fois la différence entre l'image et son flou
+// gaussien d'écart type sigma, composante par composante. Les différences inférieures à threshold
+// ne sont pas accentuées, ce qui évite d'amplifier le bruit des zones uniformes.
+func (ppm *PPM) UnsharpMask(sigma, amount float64, threshold uint8) {
+	if sigma <= 0 {
+		fmt.Println("L'écart type du flou doit être positif.")
+		return
+	}
+	kernel := GaussianKernel(sigma)
+	planes := ppm.planes()
+	for c := range planes {
+		blurred := raster.ConvolvePlane(planes[c], ppm.width, ppm.height, kernel, EdgeReplicate, 0)
+		planes[c] = raster.UnsharpPlane(planes[c], blurred, amount, float64(threshold))
+	}
+	ppm.setPlanes(planes, 0)
+}
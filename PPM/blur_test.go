@@ -0,0 +1,36 @@
+package Netpbm
+
+import "testing"
+
+func TestPPMBlur(t *testing.T) {
+	ppm := newBlankPPM(9, 9)
+	ppm.data[4][4] = Pixel{255 - 225, 255 - 45, 255}
+	ppm.BoxBlur(1)
+	if countPixels(ppm, Pixel{230, 250, 255}) != 9 {
+		t.Fatalf("BoxBlur should spread the point over 3×3 pixels: %v", ppm.data[4])
+	}
+
+	ppm = newBlankPPM(9, 9)
+	ppm.data[4][4] = Pixel{0, 255, 0}
+	ppm.GaussianBlur(1)
+	p := ppm.data[4][4]
+	if p.R == 0 || p.R == 255 || p.G != 255 || p.B == 255 || ppm.data[0][0] != (Pixel{255, 255, 255}) {
+		t.Fatalf("GaussianBlur wrong: %v", ppm.data[4])
+	}
+}
+
+func TestPPMUnsharpMask(t *testing.T) {
+	ppm := newBlankPPM(10, 3)
+	for y := range ppm.data {
+		for x := range ppm.data[y] {
+			ppm.data[y][x] = Pixel{100, 100, 100}
+			if x >= 5 {
+				ppm.data[y][x] = Pixel{150, 150, 150}
+			}
+		}
+	}
+	ppm.UnsharpMask(1, 1, 0)
+	if ppm.data[1][4].R >= 100 || ppm.data[1][5].G <= 150 || ppm.data[1][0] != (Pixel{100, 100, 100}) {
+		t.Fatalf("UnsharpMask should increase the step contrast: %v", ppm.data[1])
+	}
+}
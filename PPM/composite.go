@@ -0,0 +1,277 @@
+package Netpbm
+
+import (
+	"math"
+
+	"github.com/dada416-lebg/Netpbm/internal/raster"
+)
+
+// BlendMode définit comment la couleur de la source est combinée à celle de la destination.
+type BlendMode int
+
+const (
+	BlendNormal BlendMode = iota
+	BlendMultiply
+	BlendScreen
+	BlendOverlay
+	BlendDarken
+	BlendLighten
+	BlendDifference
+)
+
+// CompositeOp est un opérateur de Porter-Duff.
+type CompositeOp int
+
+const (
+	OpSrcOver CompositeOp = iota
+	OpClear
+	OpSrc
+	OpDst
+	OpDstOver
+	OpSrcIn
+	OpDstIn
+	OpSrcOut
+	OpDstOut
+	OpSrcAtop
+	OpDstAtop
+	OpXor
+)
+
+// Layer est une image PPM accompagnée d'un canal alpha, utilisée comme calque de composition.
+// Les valeurs alpha vont de 0 (transparent) à 1 (opaque).
+type Layer struct {
+	Image *PPM
+	Alpha [][]float64
+}
+
+// NewLayer crée un calque entièrement transparent.
+func NewLayer(width, height int) *Layer {
+	alpha := make([][]float64, height)
+	for y := range alpha {
+		alpha[y] = make([]float64, width)
+	}
+	return &Layer{NewPPM(width, height), alpha}
+}
+
+// NewLayerFromPPM crée un calque à partir d'une image PPM.
+// Le masque, s'il n'est pas nil, donne la couverture de chaque pixel de 0 (transparent) à 255 (opaque) ;
+// il n'est appelé que pour les pixels de l'image. Sans masque le calque est opaque.
+func NewLayerFromPPM(ppm *PPM, mask func(x, y int) uint8) *Layer {
+	layer := NewLayer(ppm.width, ppm.height)
+	for y := 0; y < ppm.height; y++ {
+		copy(layer.Image.data[y], ppm.data[y])
+		for x := 0; x < ppm.width; x++ {
+			layer.Alpha[y][x] = maskAlpha(mask, x, y)
+		}
+	}
+	return layer
+}
+
+// maskAlpha renvoie l'opacité donnée par le masque au pixel (x, y), 1 sans masque.
+func maskAlpha(mask func(x, y int) uint8, x, y int) float64 {
+	if mask == nil {
+		return 1
+	}
+	return float64(mask(x, y)) / 255
+}
+
+// Composite combine le calque src dans le calque, son coin supérieur gauche placé en (x, y).
+// Seuls les pixels recouverts par src sont modifiés.
+func (l *Layer) Composite(src *Layer, x, y int, op CompositeOp, mode BlendMode, opacity float64) {
+	for sy := 0; sy < src.Image.height; sy++ {
+		dy := y + sy
+		if dy < 0 || dy >= l.Image.height {
+			continue
+		}
+		for sx := 0; sx < src.Image.width; sx++ {
+			dx := x + sx
+			if dx < 0 || dx >= l.Image.width {
+				continue
+			}
+			color, alpha := compositePixel(src.Image.data[sy][sx], src.Alpha[sy][sx]*opacity,
+				l.Image.data[dy][dx], l.Alpha[dy][dx], op, mode)
+			l.Image.data[dy][dx] = color
+			l.Alpha[dy][dx] = alpha
+		}
+	}
+}
+
+// Flatten renvoie l'image du calque posée sur un fond opaque de couleur background.
+func (l *Layer) Flatten(background Pixel) *PPM {
+	result := NewLayer(l.Image.width, l.Image.height)
+	result.Image.DrawFilledRectangle(Point{0, 0}, l.Image.width, l.Image.height, background)
+	for y := range result.Alpha {
+		for x := range result.Alpha[y] {
+			result.Alpha[y][x] = 1
+		}
+	}
+	result.Composite(l, 0, 0, OpSrcOver, BlendNormal, 1)
+	return result.Image
+}
+
+// Composite combine l'image src dans l'image PPM, son coin supérieur gauche placé en (x, y).
+// L'image de destination est considérée comme opaque ; le masque, s'il n'est pas nil, donne la couverture
+// de 0 à 255 de chaque pixel de src, en coordonnées de src.
+func (ppm *PPM) Composite(src *PPM, mask func(x, y int) uint8, x, y int, op CompositeOp, mode BlendMode, opacity float64) {
+	for sy := 0; sy < src.height; sy++ {
+		dy := y + sy
+		if dy < 0 || dy >= ppm.height {
+			continue
+		}
+		for sx := 0; sx < src.width; sx++ {
+			dx := x + sx
+			if dx < 0 || dx >= ppm.width {
+				continue
+			}
+			color, alpha := compositePixel(src.data[sy][sx], maskAlpha(mask, sx, sy)*opacity,
+				ppm.data[dy][dx], 1, op, mode)
+			// Sans canal alpha, la destination transparente devient noire
+			ppm.data[dy][dx] = scalePixel(color, alpha)
+		}
+	}
+}
+
+// BlendPixel mélange color au pixel (x, y) avec l'opacité et le mode de fusion donnés.
+func (ppm *PPM) BlendPixel(x, y int, color Pixel, opacity float64, mode BlendMode) {
+	if x < 0 || x >= ppm.width || y < 0 || y >= ppm.height {
+		return
+	}
+	result, _ := compositePixel(color, opacity, ppm.data[y][x], 1, OpSrcOver, mode)
+	ppm.data[y][x] = result
+}
+
+// FillPathBlend remplit le chemin avec une couleur translucide.
+func (ppm *PPM) FillPathBlend(path *Path, color Pixel, opacity float64, mode BlendMode) {
+	raster.FillPolygons(path.Polylines(), path.FillRule, ppm.width, ppm.height, func(y, x0, x1 int) {
+		for x := x0; x < x1; x++ {
+			ppm.BlendPixel(x, y, color, opacity, mode)
+		}
+	})
+}
+
+// StrokePathBlend trace le contour du chemin avec une couleur translucide.
+// Chaque pixel du trait n'est mélangé qu'une seule fois, même aux jointures.
+func (ppm *PPM) StrokePathBlend(path *Path, width float64, color Pixel, opacity float64, mode BlendMode) {
+	blend := func(x, y int) {
+		ppm.BlendPixel(x, y, color, opacity, mode)
+	}
+	if width > 1 {
+		// Avec la règle non nulle, les segments remplis couvrent chaque pixel une seule fois
+		polygons := raster.StrokePolygons(&path.Path, width, path.EffectiveTolerance())
+		raster.FillPolygons(polygons, FillNonZero, ppm.width, ppm.height, func(y, x0, x1 int) {
+			for x := x0; x < x1; x++ {
+				blend(x, y)
+			}
+		})
+		return
+	}
+
+	// Trait fin : les segments consécutifs partagent leurs extrémités
+	seen := make(map[Point]bool)
+	once := func(x, y int) {
+		if p := (Point{x, y}); !seen[p] {
+			seen[p] = true
+			blend(x, y)
+		}
+	}
+	for _, polyline := range path.Polylines() {
+		if len(polyline) == 1 {
+			once(raster.Round(polyline[0].X), raster.Round(polyline[0].Y))
+			continue
+		}
+		for i := 0; i+1 < len(polyline); i++ {
+			bresenham(roundPoint(polyline[i]), roundPoint(polyline[i+1]), once)
+		}
+	}
+}
+
+// compositePixel combine une source et une destination non prémultipliées et renvoie la couleur et l'alpha résultants.
+func compositePixel(src Pixel, srcAlpha float64, dst Pixel, dstAlpha float64, op CompositeOp, mode BlendMode) (Pixel, float64) {
+	as := clamp01(srcAlpha)
+	ab := clamp01(dstAlpha)
+
+	// Facteurs de Porter-Duff appliqués à la source et à la destination
+	var fa, fb float64
+	switch op {
+	case OpClear:
+		fa, fb = 0, 0
+	case OpSrc:
+		fa, fb = 1, 0
+	case OpDst:
+		fa, fb = 0, 1
+	case OpSrcOver:
+		fa, fb = 1, 1-as
+	case OpDstOver:
+		fa, fb = 1-ab, 1
+	case OpSrcIn:
+		fa, fb = ab, 0
+	case OpDstIn:
+		fa, fb = 0, as
+	case OpSrcOut:
+		fa, fb = 1-ab, 0
+	case OpDstOut:
+		fa, fb = 0, 1-as
+	case OpSrcAtop:
+		fa, fb = ab, 1-as
+	case OpDstAtop:
+		fa, fb = 1-ab, as
+	case OpXor:
+		fa, fb = 1-ab, 1-as
+	}
+
+	alpha := as*fa + ab*fb
+	if alpha <= 0 {
+		return Pixel{}, 0
+	}
+
+	channel := func(cs, cb uint8) uint8 {
+		s := float64(cs) / 255
+		b := float64(cb) / 255
+		// La couleur de la source est d'abord fusionnée avec la destination là où celle-ci est présente
+		s = (1-ab)*s + ab*blendChannel(b, s, mode)
+		return toUint8((as*fa*s + ab*fb*b) / alpha * 255)
+	}
+	return Pixel{channel(src.R, dst.R), channel(src.G, dst.G), channel(src.B, dst.B)}, alpha
+}
+
+// blendChannel applique le mode de fusion à une composante de la destination b et de la source s.
+func blendChannel(b, s float64, mode BlendMode) float64 {
+	switch mode {
+	case BlendMultiply:
+		return b * s
+	case BlendScreen:
+		return b + s - b*s
+	case BlendOverlay:
+		if b <= 0.5 {
+			return 2 * b * s
+		}
+		return 1 - 2*(1-b)*(1-s)
+	case BlendDarken:
+		return math.Min(b, s)
+	case BlendLighten:
+		return math.Max(b, s)
+	case BlendDifference:
+		return math.Abs(b - s)
+	}
+	return s
+}
+
+// scalePixel multiplie chaque composante par a.
+func scalePixel(p Pixel, a float64) Pixel {
+	return Pixel{toUint8(float64(p.R) * a), toUint8(float64(p.G) * a), toUint8(float64(p.B) * a)}
+}
+
+func clamp01(v float64) float64 {
+	return math.Max(0, math.Min(1, v))
+}
+
+// toUint8 arrondit v et le ramène dans l'intervalle [0, 255].
+func toUint8(v float64) uint8 {
+	if v <= 0 {
+		return 0
+	}
+	if v >= 255 {
+		return 255
+	}
+	return uint8(v + 0.5)
+}
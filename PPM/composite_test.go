@@ -0,0 +1,159 @@
+package Netpbm
+
+import (
+	"math"
+	"testing"
+)
+
+func TestPPMBlendModes(t *testing.T) {
+	dst := Pixel{200, 100, 0}
+	src := Pixel{100, 100, 255}
+	tests := []struct {
+		mode BlendMode
+		want Pixel
+	}{
+		{BlendNormal, Pixel{100, 100, 255}},
+		{BlendMultiply, Pixel{78, 39, 0}},
+		{BlendScreen, Pixel{222, 161, 255}},
+		{BlendOverlay, Pixel{188, 78, 0}},
+		{BlendDarken, Pixel{100, 100, 0}},
+		{BlendLighten, Pixel{200, 100, 255}},
+		{BlendDifference, Pixel{100, 0, 255}},
+	}
+	for _, test := range tests {
+		ppm := newBlankPPM(1, 1)
+		ppm.data[0][0] = dst
+		ppm.BlendPixel(0, 0, src, 1, test.mode)
+		if ppm.data[0][0] != test.want {
+			t.Errorf("Blend mode %d wrong: wanted %v got %v", test.mode, test.want, ppm.data[0][0])
+		}
+	}
+}
+
+func TestPPMBlendOpacity(t *testing.T) {
+	ppm := newBlankPPM(1, 1)
+	ppm.data[0][0] = Pixel{0, 0, 0}
+	ppm.BlendPixel(0, 0, Pixel{255, 255, 255}, 0.5, BlendNormal)
+	if ppm.data[0][0] != (Pixel{128, 128, 128}) {
+		t.Errorf("Half opacity wrong: %v", ppm.data[0][0])
+	}
+	ppm.BlendPixel(0, 0, Pixel{0, 0, 0}, 0, BlendNormal)
+	if ppm.data[0][0] != (Pixel{128, 128, 128}) {
+		t.Error("Zero opacity should not change the pixel")
+	}
+}
+
+func TestPPMCompositeWithMask(t *testing.T) {
+	dst := newBlankPPM(4, 1)
+	src := newBlankPPM(2, 1)
+	src.data[0][0] = Pixel{0, 0, 0}
+	src.data[0][1] = Pixel{0, 0, 0}
+	mask := func(x, y int) uint8 {
+		return []uint8{255, 0}[x]
+	}
+
+	dst.Composite(src, mask, 1, 0, OpSrcOver, BlendNormal, 1)
+	want := []Pixel{{255, 255, 255}, {0, 0, 0}, {255, 255, 255}, {255, 255, 255}}
+	for x, p := range want {
+		if dst.data[0][x] != p {
+			t.Errorf("Pixel at (%d, 0) wrong: wanted %v got %v", x, p, dst.data[0][x])
+		}
+	}
+}
+
+func TestLayerPorterDuff(t *testing.T) {
+	red := Pixel{255, 0, 0}
+	blue := Pixel{0, 0, 255}
+	newPair := func() (*Layer, *Layer) {
+		// La destination couvre la moitié gauche, la source la moitié droite et le centre
+		dst := NewLayer(3, 1)
+		src := NewLayer(3, 1)
+		for x := 0; x < 3; x++ {
+			dst.Image.data[0][x], src.Image.data[0][x] = red, blue
+		}
+		dst.Alpha[0][0], dst.Alpha[0][1] = 1, 1
+		src.Alpha[0][1], src.Alpha[0][2] = 1, 1
+		return dst, src
+	}
+
+	tests := []struct {
+		op    CompositeOp
+		alpha [3]float64
+		color [3]Pixel
+	}{
+		{OpSrcOver, [3]float64{1, 1, 1}, [3]Pixel{red, blue, blue}},
+		{OpDstOver, [3]float64{1, 1, 1}, [3]Pixel{red, red, blue}},
+		{OpSrcIn, [3]float64{0, 1, 0}, [3]Pixel{{}, blue, {}}},
+		{OpDstOut, [3]float64{1, 0, 0}, [3]Pixel{red, {}, {}}},
+		{OpSrcAtop, [3]float64{1, 1, 0}, [3]Pixel{red, blue, {}}},
+		{OpXor, [3]float64{1, 0, 1}, [3]Pixel{red, {}, blue}},
+		{OpClear, [3]float64{0, 0, 0}, [3]Pixel{}},
+	}
+	for _, test := range tests {
+		dst, src := newPair()
+		dst.Composite(src, 0, 0, test.op, BlendNormal, 1)
+		for x := 0; x < 3; x++ {
+			if dst.Alpha[0][x] != test.alpha[x] || dst.Image.data[0][x] != test.color[x] {
+				t.Errorf("Operator %d wrong at %d: got %v alpha %f", test.op, x, dst.Image.data[0][x], dst.Alpha[0][x])
+			}
+		}
+	}
+}
+
+func TestLayerFlatten(t *testing.T) {
+	layer := NewLayer(2, 1)
+	layer.Image.data[0][0] = Pixel{0, 0, 0}
+	layer.Alpha[0][0] = 0.5
+	ppm := layer.Flatten(Pixel{255, 255, 255})
+	if ppm.data[0][0] != (Pixel{128, 128, 128}) || ppm.data[0][1] != (Pixel{255, 255, 255}) {
+		t.Errorf("Flatten wrong: %v", ppm.data[0])
+	}
+}
+
+func TestPPMStrokePathBlend(t *testing.T) {
+	ppm := newBlankPPM(10, 10)
+	for y := range ppm.data {
+		for x := range ppm.data[y] {
+			ppm.data[y][x] = Pixel{0, 0, 0}
+		}
+	}
+	path := NewPath()
+	path.MoveTo(1, 1)
+	path.LineTo(8, 1)
+	path.LineTo(8, 8)
+	ppm.StrokePathBlend(path, 1, Pixel{200, 200, 200}, 0.5, BlendNormal)
+	// Le coin partagé par les deux segments ne doit pas être mélangé deux fois
+	if ppm.data[1][8] != (Pixel{100, 100, 100}) || ppm.data[1][4] != (Pixel{100, 100, 100}) {
+		t.Errorf("Translucent stroke wrong: %v %v", ppm.data[1][8], ppm.data[1][4])
+	}
+
+	// Trait épais : les jointures arrondies se recouvrent sans double mélange
+	ppm = newBlankPPM(20, 20)
+	path = NewPath()
+	path.MoveTo(3, 3)
+	path.LineTo(15, 3)
+	path.LineTo(15, 15)
+	ppm.StrokePathBlend(path, 4, Pixel{0, 0, 0}, 0.5, BlendNormal)
+	half := Pixel{128, 128, 128}
+	if ppm.data[3][15] != half || ppm.data[3][8] != half || ppm.data[10][15] != half {
+		t.Errorf("Wide translucent stroke wrong: %v %v %v", ppm.data[3][15], ppm.data[3][8], ppm.data[10][15])
+	}
+	if countPixels(ppm, half)+countPixels(ppm, Pixel{255, 255, 255}) != 400 {
+		t.Error("Every pixel should be blended at most once")
+	}
+}
+
+func TestNewLayerFromPPMMask(t *testing.T) {
+	ppm := newBlankPPM(3, 1)
+	layer := NewLayerFromPPM(ppm, func(x, y int) uint8 {
+		return uint8(x * 51)
+	})
+	for x, want := range []float64{0, 0.2, 0.4} {
+		if math.Abs(layer.Alpha[0][x]-want) > 1e-9 {
+			t.Errorf("Alpha at (%d, 0) wrong: wanted %v got %v", x, want, layer.Alpha[0][x])
+		}
+	}
+	if NewLayerFromPPM(ppm, nil).Alpha[0][2] != 1 {
+		t.Error("Layer without mask should be opaque")
+	}
+}
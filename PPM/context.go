@@ -0,0 +1,278 @@
+package Netpbm
+
+import (
+	"math"
+
+	"github.com/dada416-lebg/Netpbm/internal/raster"
+)
+
+// Rect est un rectangle de pixels de coin supérieur gauche (X, Y).
+type Rect struct {
+	X, Y, Width, Height int
+}
+
+// Empty indique si le rectangle ne contient aucun pixel.
+func (r Rect) Empty() bool {
+	return r.Width <= 0 || r.Height <= 0
+}
+
+// Contains indique si le pixel (x, y) est dans le rectangle.
+func (r Rect) Contains(x, y int) bool {
+	return x >= r.X && x < r.X+r.Width && y >= r.Y && y < r.Y+r.Height
+}
+
+// Intersect renvoie l'intersection des deux rectangles, de taille nulle s'ils sont disjoints.
+func (r Rect) Intersect(s Rect) Rect {
+	x0, y0 := max(r.X, s.X), max(r.Y, s.Y)
+	x1, y1 := min(r.X+r.Width, s.X+s.Width), min(r.Y+r.Height, s.Y+s.Height)
+	if x1 <= x0 || y1 <= y0 {
+		return Rect{x0, y0, 0, 0}
+	}
+	return Rect{x0, y0, x1 - x0, y1 - y0}
+}
+
+// Context dessine dans une image PPM à travers une transformation affine et une zone de découpe.
+// Les coordonnées passées aux méthodes de dessin sont exprimées dans le repère courant,
+// ce qui permet de réutiliser le même code de dessin à différentes positions et tailles.
+type Context struct {
+	image *PPM
+	state contextState
+	stack []contextState
+}
+
+// contextState est l'état sauvegardé par Save et restauré par Restore.
+type contextState struct {
+	matrix Matrix
+	clip   Rect
+	mask   func(x, y int) bool
+}
+
+// NewContext crée un contexte de dessin sur l'image, sans transformation ni découpe.
+func NewContext(ppm *PPM) *Context {
+	return &Context{
+		image: ppm,
+		state: contextState{
+			matrix: IdentityMatrix(),
+			clip:   Rect{0, 0, ppm.width, ppm.height},
+		},
+	}
+}
+
+// Image renvoie l'image dans laquelle le contexte dessine.
+func (ctx *Context) Image() *PPM {
+	return ctx.image
+}
+
+// Save mémorise la transformation et la découpe courantes.
+func (ctx *Context) Save() {
+	ctx.stack = append(ctx.stack, ctx.state)
+}
+
+// Restore rétablit la transformation et la découpe mémorisées par le dernier appel à Save.
+func (ctx *Context) Restore() {
+	if len(ctx.stack) == 0 {
+		return
+	}
+	ctx.state = ctx.stack[len(ctx.stack)-1]
+	ctx.stack = ctx.stack[:len(ctx.stack)-1]
+}
+
+// Matrix renvoie la transformation courante.
+func (ctx *Context) Matrix() Matrix {
+	return ctx.state.matrix
+}
+
+// SetMatrix remplace la transformation courante.
+func (ctx *Context) SetMatrix(m Matrix) {
+	ctx.state.matrix = m
+}
+
+// Translate déplace l'origine du repère courant de (tx, ty).
+func (ctx *Context) Translate(tx, ty float64) {
+	ctx.state.matrix = ctx.state.matrix.Translate(tx, ty)
+}
+
+// Rotate tourne le repère courant de angle degrés autour de son origine.
+func (ctx *Context) Rotate(angle float64) {
+	ctx.state.matrix = ctx.state.matrix.Rotate(angle)
+}
+
+// Scale agrandit le repère courant des facteurs (sx, sy).
+func (ctx *Context) Scale(sx, sy float64) {
+	ctx.state.matrix = ctx.state.matrix.Scale(sx, sy)
+}
+
+// ClipRect restreint le dessin au rectangle r, exprimé en pixels de l'image.
+// La découpe s'ajoute à la découpe courante.
+func (ctx *Context) ClipRect(r Rect) {
+	ctx.state.clip = ctx.state.clip.Intersect(r)
+}
+
+// ClipMask restreint le dessin aux pixels (x, y) de l'image pour lesquels inside renvoie true.
+// inside n'est appelé que pour des pixels de l'image ; c'est à lui de décider du sort des pixels
+// situés hors de son propre masque. La découpe s'ajoute à la découpe courante : un pixel n'est dessiné
+// que si tous les masques et rectangles de découpe l'acceptent. Un masque nil ne change rien.
+func (ctx *Context) ClipMask(inside func(x, y int) bool) {
+	if inside == nil {
+		return
+	}
+	if previous := ctx.state.mask; previous != nil {
+		ctx.state.mask = func(x, y int) bool {
+			return previous(x, y) && inside(x, y)
+		}
+		return
+	}
+	ctx.state.mask = inside
+}
+
+// ResetClip supprime la découpe.
+func (ctx *Context) ResetClip() {
+	ctx.state.clip = Rect{0, 0, ctx.image.width, ctx.image.height}
+	ctx.state.mask = nil
+}
+
+// visible indique si le pixel (x, y) de l'image peut être modifié.
+func (ctx *Context) visible(x, y int) bool {
+	if !ctx.state.clip.Contains(x, y) {
+		return false
+	}
+	return ctx.state.mask == nil || ctx.state.mask(x, y)
+}
+
+// plot colore le pixel (x, y) de l'image s'il n'est pas découpé.
+func (ctx *Context) plot(x, y int, color Pixel) {
+	if ctx.visible(x, y) {
+		ctx.image.data[y][x] = color
+	}
+}
+
+// span colore les pixels [x0, x1) de la ligne y qui ne sont pas découpés.
+func (ctx *Context) span(color Pixel) func(y, x0, x1 int) {
+	return func(y, x0, x1 int) {
+		for x := x0; x < x1; x++ {
+			ctx.plot(x, y, color)
+		}
+	}
+}
+
+// scale renvoie le facteur d'échelle moyen de la transformation courante.
+func (ctx *Context) scale() float64 {
+	m := ctx.state.matrix
+	return math.Sqrt(math.Abs(m.A*m.D - m.B*m.C))
+}
+
+// SetPixel colore le pixel qui contient le point (x, y).
+func (ctx *Context) SetPixel(x, y float64, color Pixel) {
+	p := ctx.state.matrix.Apply(PointF{X: x, Y: y})
+	ctx.plot(int(math.Floor(p.X)), int(math.Floor(p.Y)), color)
+}
+
+// DrawLine trace un segment d'un pixel d'épaisseur entre (x1, y1) et (x2, y2).
+func (ctx *Context) DrawLine(x1, y1, x2, y2 float64, color Pixel) {
+	p1 := ctx.state.matrix.Apply(PointF{X: x1, Y: y1})
+	p2 := ctx.state.matrix.Apply(PointF{X: x2, Y: y2})
+	bresenham(roundPoint(p1), roundPoint(p2), func(x, y int) {
+		ctx.plot(x, y, color)
+	})
+}
+
+// StrokePath trace le contour du chemin. L'épaisseur width, exprimée dans le repère courant,
+// suit l'échelle de la transformation ; en dessous d'un pixel, le trait fait un pixel.
+func (ctx *Context) StrokePath(path *Path, width float64, color Pixel) {
+	device := path.Transform(ctx.state.matrix)
+	width *= ctx.scale()
+	if width <= 1 {
+		for _, polyline := range device.Polylines() {
+			if len(polyline) == 1 {
+				ctx.plot(raster.Round(polyline[0].X), raster.Round(polyline[0].Y), color)
+			}
+			for i := 0; i+1 < len(polyline); i++ {
+				bresenham(roundPoint(polyline[i]), roundPoint(polyline[i+1]), func(x, y int) {
+					ctx.plot(x, y, color)
+				})
+			}
+		}
+		return
+	}
+	polygons := raster.StrokePolygons(&device.Path, width, device.EffectiveTolerance())
+	raster.FillPolygons(polygons, FillNonZero, ctx.image.width, ctx.image.height, ctx.span(color))
+}
+
+// FillPath remplit l'intérieur du chemin selon sa règle de remplissage.
+func (ctx *Context) FillPath(path *Path, color Pixel) {
+	device := path.Transform(ctx.state.matrix)
+	raster.FillPolygons(device.Polylines(), device.FillRule, ctx.image.width, ctx.image.height, ctx.span(color))
+}
+
+// FillPathWithPaint remplit le chemin avec une peinture, évaluée dans le repère courant.
+func (ctx *Context) FillPathWithPaint(path *Path, paint Paint) {
+	inverse, ok := ctx.state.matrix.Invert()
+	if !ok {
+		return
+	}
+	device := path.Transform(ctx.state.matrix)
+	raster.FillPolygons(device.Polylines(), device.FillRule, ctx.image.width, ctx.image.height, func(y, x0, x1 int) {
+		for x := x0; x < x1; x++ {
+			if ctx.visible(x, y) {
+				p := inverse.Apply(PointF{X: float64(x) + 0.5, Y: float64(y) + 0.5})
+				ctx.image.data[y][x] = paint.ColorAt(p.X, p.Y)
+			}
+		}
+	})
+}
+
+// rectanglePath renvoie le chemin d'un rectangle.
+func rectanglePath(x, y, width, height float64) *Path {
+	path := NewPath()
+	path.MoveTo(x, y)
+	path.LineTo(x+width, y)
+	path.LineTo(x+width, y+height)
+	path.LineTo(x, y+height)
+	path.Close()
+	return path
+}
+
+// circlePath renvoie le chemin d'un cercle, aplati assez finement pour la transformation courante.
+func (ctx *Context) circlePath(cx, cy, r float64) *Path {
+	path := NewPath()
+	if s := ctx.scale(); s > 0 {
+		path.Tolerance = raster.DefaultTolerance / s
+	}
+	path.Arc(cx, cy, r, 0, 2*math.Pi)
+	path.Close()
+	return path
+}
+
+// DrawRectangle trace le contour d'un rectangle.
+func (ctx *Context) DrawRectangle(x, y, width, height, lineWidth float64, color Pixel) {
+	ctx.StrokePath(rectanglePath(x, y, width, height), lineWidth, color)
+}
+
+// FillRectangle remplit un rectangle.
+func (ctx *Context) FillRectangle(x, y, width, height float64, color Pixel) {
+	ctx.FillPath(rectanglePath(x, y, width, height), color)
+}
+
+// DrawCircle trace le contour d'un cercle.
+func (ctx *Context) DrawCircle(cx, cy, r, lineWidth float64, color Pixel) {
+	ctx.StrokePath(ctx.circlePath(cx, cy, r), lineWidth, color)
+}
+
+// FillCircle remplit un disque.
+func (ctx *Context) FillCircle(cx, cy, r float64, color Pixel) {
+	ctx.FillPath(ctx.circlePath(cx, cy, r), color)
+}
+
+// FillPolygon remplit le polygone dont les sommets sont points.
+func (ctx *Context) FillPolygon(points []PointF, color Pixel) {
+	if len(points) < 3 {
+		return
+	}
+	path := NewPath()
+	path.MoveTo(points[0].X, points[0].Y)
+	for _, p := range points[1:] {
+		path.LineTo(p.X, p.Y)
+	}
+	path.Close()
+	ctx.FillPath(path, color)
+}
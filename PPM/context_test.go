@@ -0,0 +1,110 @@
+package Netpbm
+
+import "testing"
+
+func TestRectIntersect(t *testing.T) {
+	r := Rect{0, 0, 10, 10}.Intersect(Rect{5, -5, 10, 8})
+	if r != (Rect{5, 0, 5, 3}) {
+		t.Errorf("Intersect wrong: %v", r)
+	}
+	if !(Rect{0, 0, 2, 2}).Intersect(Rect{3, 3, 2, 2}).Empty() {
+		t.Error("Disjoint rectangles should give an empty intersection")
+	}
+}
+
+func TestContextTransform(t *testing.T) {
+	ppm := newBlankPPM(40, 40)
+	ctx := NewContext(ppm)
+	color := Pixel{255, 0, 0}
+
+	// Le même carré unité, dessiné à deux endroits et à deux tailles
+	ctx.Save()
+	ctx.Translate(5, 5)
+	ctx.Scale(4, 4)
+	ctx.FillRectangle(0, 0, 1, 1, color)
+	ctx.Restore()
+	ctx.Translate(20, 20)
+	ctx.Scale(10, 10)
+	ctx.FillRectangle(0, 0, 1, 1, color)
+
+	if got := countPixels(ppm, color); got != 16+100 {
+		t.Errorf("Wanted 116 pixels got %d", got)
+	}
+	if ppm.data[5][5] != color || ppm.data[8][8] != color || ppm.data[9][9] == color || ppm.data[29][29] != color {
+		t.Error("Squares are misplaced")
+	}
+	if ctx.Matrix() != TranslateMatrix(20, 20).Scale(10, 10) {
+		t.Error("Matrix should combine the transforms")
+	}
+}
+
+func TestContextRotate(t *testing.T) {
+	ppm := newBlankPPM(20, 20)
+	ctx := NewContext(ppm)
+	color := Pixel{0, 0, 255}
+	ctx.Translate(10, 10)
+	ctx.Rotate(90)
+	// Un rectangle vers la droite devient, après rotation, un rectangle vers le bas
+	ctx.FillRectangle(0, -1, 8, 2, color)
+	if ppm.data[15][10] != color || ppm.data[10][15] == color {
+		t.Error("Rotation wrong")
+	}
+}
+
+func TestContextClip(t *testing.T) {
+	ppm := newBlankPPM(20, 20)
+	ctx := NewContext(ppm)
+	color := Pixel{0, 0, 0}
+	ctx.ClipRect(Rect{5, 5, 10, 10})
+	ctx.FillCircle(10, 10, 20, color)
+	ctx.DrawLine(0, 2, 19, 2, color)
+	if got := countPixels(ppm, color); got != 100 {
+		t.Errorf("Clip rect: wanted 100 pixels got %d", got)
+	}
+
+	// Le masque limite le dessin à une diagonale
+	ppm = newBlankPPM(20, 20)
+	ctx = NewContext(ppm)
+	ctx.Save()
+	ctx.ClipMask(func(x, y int) bool {
+		return x == y
+	})
+	ctx.ClipMask(nil)
+	ctx.FillRectangle(0, 0, 20, 20, color)
+	ctx.Restore()
+	if got := countPixels(ppm, color); got != 20 || ppm.data[7][7] != color {
+		t.Errorf("Clip mask: wanted 20 pixels got %d", got)
+	}
+
+	// Les masques successifs se combinent par intersection
+	ppm = newBlankPPM(20, 20)
+	ctx = NewContext(ppm)
+	ctx.ClipMask(func(x, y int) bool { return x < 10 })
+	ctx.ClipMask(func(x, y int) bool { return y < 5 })
+	ctx.FillRectangle(0, 0, 20, 20, color)
+	if got := countPixels(ppm, color); got != 50 {
+		t.Errorf("Combined clip masks: wanted 50 pixels got %d", got)
+	}
+	ctx.ResetClip()
+
+	// Après Restore ou ResetClip, la découpe n'est plus active
+	ctx.FillRectangle(0, 0, 20, 20, color)
+	if countPixels(ppm, color) != 400 {
+		t.Error("Restore should remove the clip")
+	}
+}
+
+func TestContextStrokeScale(t *testing.T) {
+	ppm := newBlankPPM(40, 40)
+	ctx := NewContext(ppm)
+	color := Pixel{0, 128, 0}
+	ctx.Scale(4, 4)
+	// Un trait d'épaisseur 1 dans le repère courant fait 4 pixels dans l'image
+	path := NewPath()
+	path.MoveTo(1, 5)
+	path.LineTo(9, 5)
+	ctx.StrokePath(path, 1, color)
+	if ppm.data[18][20] != color || ppm.data[21][20] != color || ppm.data[22][20] == color || ppm.data[17][20] == color {
+		t.Error("Stroke width should follow the scale")
+	}
+}
@@ -0,0 +1,102 @@
+package Netpbm
+
+import (
+	"math"
+
+	"github.com/dada416-lebg/Netpbm/internal/raster"
+)
+
+// Kernel est un noyau de convolution de Width × Height coefficients, rangés ligne par ligne.
+// Le pixel traité est placé au centre du noyau (Width/2, Height/2).
+type Kernel = raster.Kernel
+
+// NewKernel crée un noyau à partir de ses lignes, qui doivent toutes avoir la même longueur.
+// Un noyau séparable est reconnu automatiquement et appliqué en deux passes, une horizontale et une verticale.
+func NewKernel(rows [][]float64) Kernel {
+	return raster.NewKernel(rows)
+}
+
+// NewSeparableKernel crée le noyau produit d'une colonne et d'une ligne : la ligne est appliquée
+// horizontalement puis la colonne verticalement.
+func NewSeparableKernel(row, column []float64) Kernel {
+	return raster.NewSeparableKernel(row, column)
+}
+
+// BoxKernel renvoie le noyau moyenneur de taille size × size.
+func BoxKernel(size int) Kernel {
+	return raster.BoxKernel(size)
+}
+
+// SharpenKernel renvoie un noyau 3 × 3 qui accentue les contours.
+func SharpenKernel() Kernel {
+	return raster.SharpenKernel()
+}
+
+// EmbossKernel renvoie un noyau 3 × 3 d'estampage, éclairé depuis le coin supérieur gauche.
+// Il s'utilise avec un décalage Bias de la moitié de la valeur maximale.
+func EmbossKernel() Kernel {
+	return raster.EmbossKernel()
+}
+
+// ConvolveOptions regroupe les paramètres d'une convolution.
+type ConvolveOptions struct {
+	// Edge définit les pixels utilisés au-delà des bords de l'image.
+	Edge EdgeMode
+	// Fill est la couleur des pixels hors de l'image avec EdgeConstant.
+	Fill Pixel
+	// Normalize divise le noyau par la somme de ses coefficients.
+	Normalize bool
+	// Bias est ajouté au résultat, par exemple pour centrer un estampage sur le gris moyen.
+	Bias float64
+}
+
+// Convolve applique le noyau à chaque composante de l'image. Le noyau est appliqué tel quel,
+// sans être retourné, comme le font la plupart des logiciels de retouche. Les résultats sont
+// arrondis et ramenés entre 0 et la valeur maximale de l'image.
+func (ppm *PPM) Convolve(k Kernel, options ConvolveOptions) {
+	if k.Width == 0 || k.Height == 0 {
+		return
+	}
+	if options.Normalize {
+		k = k.Normalized()
+	}
+	planes := ppm.planes()
+	fill := [3]float64{float64(options.Fill.R), float64(options.Fill.G), float64(options.Fill.B)}
+	for c := range planes {
+		planes[c] = raster.ConvolvePlane(planes[c], ppm.width, ppm.height, k, options.Edge, fill[c])
+	}
+	ppm.setPlanes(planes, options.Bias)
+}
+
+// planes renvoie les composantes rouge, verte et bleue de l'image sous forme de tableaux de flottants.
+func (ppm *PPM) planes() [3][]float64 {
+	var planes [3][]float64
+	for c := range planes {
+		planes[c] = make([]float64, ppm.width*ppm.height)
+	}
+	for y := 0; y < ppm.height; y++ {
+		for x := 0; x < ppm.width; x++ {
+			p := ppm.data[y][x]
+			i := y*ppm.width + x
+			planes[0][i], planes[1][i], planes[2][i] = float64(p.R), float64(p.G), float64(p.B)
+		}
+	}
+	return planes
+}
+
+// setPlanes remplace les composantes de l'image par celles des tableaux augmentées de bias, arrondies et bornées.
+func (ppm *PPM) setPlanes(planes [3][]float64, bias float64) {
+	maxValue := float64(ppm.max)
+	if ppm.max <= 0 {
+		maxValue = 255
+	}
+	value := func(c, i int) uint8 {
+		return toUint8(math.Min(planes[c][i]+bias, maxValue))
+	}
+	for y := 0; y < ppm.height; y++ {
+		for x := 0; x < ppm.width; x++ {
+			i := y*ppm.width + x
+			ppm.data[y][x] = Pixel{value(0, i), value(1, i), value(2, i)}
+		}
+	}
+}
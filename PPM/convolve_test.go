@@ -0,0 +1,36 @@
+package Netpbm
+
+import "testing"
+
+func TestPPMKernelSeparable(t *testing.T) {
+	if !BoxKernel(3).Separable() || SharpenKernel().Separable() {
+		t.Fatal("Separability detection wrong")
+	}
+	if !NewKernel([][]float64{{-1, 0, 1}, {-2, 0, 2}, {-1, 0, 1}}).Separable() {
+		t.Fatal("Sobel kernel should be separable")
+	}
+}
+
+func TestPPMConvolve(t *testing.T) {
+	ppm := newBlankPPM(5, 4)
+	for y := range ppm.data {
+		for x := range ppm.data[y] {
+			ppm.data[y][x] = Pixel{200, 100, 30}
+		}
+	}
+	ppm.Convolve(NewKernel([][]float64{{1, 2, 1}, {2, 4, 2}, {1, 2, 1}}), ConvolveOptions{Edge: EdgeReflect, Normalize: true})
+	if countPixels(ppm, Pixel{200, 100, 30}) != 20 {
+		t.Fatalf("Blur of a flat image changed it: %v", ppm.data)
+	}
+
+	emboss := newBlankPPM(5, 4)
+	emboss.Convolve(EmbossKernel(), ConvolveOptions{Edge: EdgeReplicate, Bias: 128})
+	if countPixels(emboss, Pixel{128, 128, 128}) != 20 {
+		t.Fatalf("Emboss of a flat image should be 128: %v", emboss.data)
+	}
+
+	ppm.Convolve(BoxKernel(3), ConvolveOptions{Edge: EdgeConstant, Fill: Pixel{20, 10, 3}})
+	if ppm.data[1][1] != (Pixel{200, 100, 30}) || ppm.data[0][0] != (Pixel{100, 50, 15}) {
+		t.Fatalf("Constant edge wrong: %v", ppm.data[:2])
+	}
+}
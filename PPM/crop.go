@@ -0,0 +1,90 @@
+package Netpbm
+
+import (
+	"fmt"
+
+	"github.com/dada416-lebg/Netpbm/internal/raster"
+)
+
+// EdgeMode définit la valeur des pixels situés hors de l'image.
+type EdgeMode = raster.EdgeMode
+
+const (
+	// EdgeConstant utilise une couleur fixe.
+	EdgeConstant = raster.EdgeConstant
+	// EdgeReplicate prolonge le pixel du bord le plus proche.
+	EdgeReplicate = raster.EdgeReplicate
+	// EdgeReflect reflète l'image comme dans un miroir posé sur le bord, pixel du bord compris (dcba|abcd|dcba).
+	EdgeReflect = raster.EdgeReflect
+	// EdgeWrap répète l'image comme une mosaïque.
+	EdgeWrap = raster.EdgeWrap
+)
+
+// atEdge renvoie le pixel (x, y), éventuellement hors de l'image, selon le mode de bord.
+func (ppm *PPM) atEdge(x, y int, mode EdgeMode, fill Pixel) Pixel {
+	sx, okX := raster.EdgeIndex(x, ppm.width, mode)
+	sy, okY := raster.EdgeIndex(y, ppm.height, mode)
+	if !okX || !okY {
+		return fill
+	}
+	return ppm.data[sy][sx]
+}
+
+// Crop ne garde que la partie de l'image située dans le rectangle r.
+func (ppm *PPM) Crop(r Rect) {
+	r = r.Intersect(Rect{0, 0, ppm.width, ppm.height})
+	if r.Empty() {
+		fmt.Println("La zone à découper est en dehors de l'image.")
+		return
+	}
+	data := make([][]Pixel, r.Height)
+	for y := range data {
+		data[y] = append([]Pixel(nil), ppm.data[r.Y+y][r.X:r.X+r.Width]...)
+	}
+	ppm.data = data
+	ppm.width, ppm.height = r.Width, r.Height
+}
+
+// Pad ajoute autour de l'image des bordures de la couleur fill, de l'épaisseur donnée pour chaque côté.
+func (ppm *PPM) Pad(top, right, bottom, left int, fill Pixel) {
+	ppm.Extend(top, right, bottom, left, EdgeConstant, fill)
+}
+
+// Extend agrandit l'image de l'épaisseur donnée pour chaque côté. Les nouveaux pixels sont obtenus
+// selon le mode de bord ; fill n'est utilisé qu'avec EdgeConstant.
+func (ppm *PPM) Extend(top, right, bottom, left int, mode EdgeMode, fill Pixel) {
+	if top < 0 || right < 0 || bottom < 0 || left < 0 {
+		fmt.Println("Les épaisseurs des bordures doivent être positives.")
+		return
+	}
+	width, height := ppm.width+left+right, ppm.height+top+bottom
+	data := make([][]Pixel, height)
+	for y := range data {
+		data[y] = make([]Pixel, width)
+		for x := range data[y] {
+			data[y][x] = ppm.atEdge(x-left, y-top, mode, fill)
+		}
+	}
+	ppm.data = data
+	ppm.width, ppm.height = width, height
+}
+
+// Trim retire les bordures uniformes de l'image, de la couleur du pixel en haut à gauche, et renvoie
+// le rectangle conservé. Un pixel fait partie de la bordure si aucune de ses composantes ne s'écarte
+// de plus de tolerance de cette couleur. Une image entièrement uniforme n'est pas modifiée.
+func (ppm *PPM) Trim(tolerance int) Rect {
+	if ppm.width == 0 || ppm.height == 0 {
+		return Rect{}
+	}
+	border := ppm.data[0][0]
+	isBorder := func(x, y int) bool {
+		return colorDistance(ppm.data[y][x], border) <= tolerance
+	}
+	x, y, w, h, ok := raster.ContentBounds(ppm.width, ppm.height, isBorder)
+	if !ok {
+		return Rect{0, 0, ppm.width, ppm.height}
+	}
+	r := Rect{x, y, w, h}
+	ppm.Crop(r)
+	return r
+}
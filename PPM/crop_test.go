@@ -0,0 +1,78 @@
+package Netpbm
+
+import "testing"
+
+func TestPPMCrop(t *testing.T) {
+	ppm := numberedPPM()
+	ppm.Crop(Rect{1, -3, 10, 4})
+	if ppm.width != 2 || ppm.height != 1 || ppm.data[0][0].R != 1 || ppm.data[0][1].R != 2 {
+		t.Errorf("Crop wrong: %v", redRows(ppm))
+	}
+}
+
+func TestPPMExtendModes(t *testing.T) {
+	tests := []struct {
+		mode EdgeMode
+		want []uint8
+	}{
+		{EdgeConstant, []uint8{99, 99, 0, 1, 2, 99, 99}},
+		{EdgeReplicate, []uint8{0, 0, 0, 1, 2, 2, 2}},
+		{EdgeReflect, []uint8{1, 0, 0, 1, 2, 2, 1}},
+		{EdgeWrap, []uint8{1, 2, 0, 1, 2, 0, 1}},
+	}
+	for _, test := range tests {
+		ppm := numberedPPM()
+		ppm.Extend(1, 2, 0, 2, test.mode, Pixel{99, 0, 0})
+		if ppm.width != 7 || ppm.height != 3 {
+			t.Fatalf("Mode %d: wrong size %dx%d", test.mode, ppm.width, ppm.height)
+		}
+		// La ligne 1 de l'image agrandie est la première ligne d'origine
+		for x, want := range test.want {
+			if ppm.data[1][x].R != want {
+				t.Errorf("Mode %d: wanted %v got %v", test.mode, test.want, redRows(ppm)[1])
+				break
+			}
+		}
+	}
+}
+
+func TestPPMPad(t *testing.T) {
+	ppm := numberedPPM()
+	fill := Pixel{1, 2, 3}
+	ppm.Pad(1, 2, 3, 4, fill)
+	if ppm.width != 9 || ppm.height != 6 || countPixels(ppm, fill) != 54-6 || ppm.data[1][4].R != 0 {
+		t.Error("Pad wrong")
+	}
+}
+
+func TestPPMTrim(t *testing.T) {
+	ppm := newBlankPPM(20, 10)
+	ppm.data[0][0] = Pixel{250, 252, 255}
+	ppm.data[9][19] = Pixel{248, 255, 255}
+	ppm.DrawFilledRectangle(Point{5, 3}, 4, 2, Pixel{0, 0, 0})
+	ppm.data[6][12] = Pixel{10, 10, 10}
+
+	r := ppm.Trim(8)
+	if r != (Rect{5, 3, 8, 4}) || ppm.width != 8 || ppm.height != 4 {
+		t.Errorf("Trim kept %v", r)
+	}
+	if ppm.data[0][0] != (Pixel{0, 0, 0}) || ppm.data[3][7] != (Pixel{10, 10, 10}) {
+		t.Error("Trim cropped the wrong area")
+	}
+
+	blank := newBlankPPM(4, 4)
+	if r := blank.Trim(0); r != (Rect{0, 0, 4, 4}) || blank.width != 4 {
+		t.Error("A uniform image should not be trimmed")
+	}
+}
+
+func TestPPMExtendEmpty(t *testing.T) {
+	red := Pixel{255, 0, 0}
+	for _, mode := range []EdgeMode{EdgeConstant, EdgeReplicate, EdgeReflect, EdgeWrap} {
+		ppm := newBlankPPM(0, 0)
+		ppm.Extend(1, 2, 1, 2, mode, red)
+		if ppm.width != 4 || ppm.height != 2 || countPixels(ppm, red) != 8 {
+			t.Errorf("Mode %d: extending an empty image should fill it, got %dx%d", mode, ppm.width, ppm.height)
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package Netpbm
+
+import (
+	"fmt"
+
+	"github.com/dada416-lebg/Netpbm/internal/raster"
+)
+
+// Bilateral lisse l'image en préservant les contours : chaque pixel devient la moyenne de ses voisins,
+// pondérée à la fois par leur distance (écart type spatialSigma, en pixels) et par leur différence
+// de couleur (écart type rangeSigma, distance euclidienne entre les composantes). Les trois composantes
+// sont pondérées ensemble pour ne pas créer de franges colorées. Les lignes sont traitées en parallèle.
+func (ppm *PPM) Bilateral(spatialSigma, rangeSigma float64) {
+	if spatialSigma <= 0 || rangeSigma <= 0 {
+		fmt.Println("Les écarts types du filtre bilatéral doivent être positifs.")
+		return
+	}
+	planes := ppm.planes()
+	result := raster.BilateralPlanes(planes[:], ppm.width, ppm.height, spatialSigma, rangeSigma)
+	ppm.setPlanes([3][]float64(result), 0)
+}
+
+// NonLocalMeansOptions regroupe les paramètres du débruitage par moyennes non locales.
+type NonLocalMeansOptions = raster.NonLocalMeansOptions
+
+// DefaultNonLocalMeansOptions renvoie des paramètres adaptés à un bruit modéré sur une image 8 bits par composante.
+func DefaultNonLocalMeansOptions() NonLocalMeansOptions {
+	return NonLocalMeansOptions{PatchRadius: 1, SearchRadius: 7, H: 10}
+}
+
+// NonLocalMeans débruite l'image en remplaçant chaque pixel par la moyenne des pixels de la zone
+// de recherche, pondérée par la ressemblance de leurs voisinages avec celui du pixel. Les lignes
+// sont traitées en parallèle. Les voisinages sont comparés sur les trois composantes à la fois.
+func (ppm *PPM) NonLocalMeans(options NonLocalMeansOptions) {
+	if options.PatchRadius < 0 || options.SearchRadius <= 0 || options.H <= 0 {
+		fmt.Println("Les paramètres des moyennes non locales ne sont pas valides.")
+		return
+	}
+	planes := ppm.planes()
+	result := raster.NonLocalMeansPlanes(planes[:], ppm.width, ppm.height, options)
+	ppm.setPlanes([3][]float64(result), 0)
+}
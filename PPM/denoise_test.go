@@ -0,0 +1,62 @@
+package Netpbm
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// noisyEdgePPM crée une image rouge à gauche et bleue à droite, avec un bruit uniforme de ±8 par composante.
+func noisyEdgePPM() (*PPM, func(x int) Pixel) {
+	want := func(x int) Pixel {
+		if x >= 10 {
+			return Pixel{40, 40, 200}
+		}
+		return Pixel{200, 40, 40}
+	}
+	rng := rand.New(rand.NewSource(3))
+	noise := func(v uint8) uint8 { return uint8(int(v) + rng.Intn(17) - 8) }
+	ppm := newBlankPPM(20, 12)
+	for y := range ppm.data {
+		for x := range ppm.data[y] {
+			p := want(x)
+			ppm.data[y][x] = Pixel{noise(p.R), noise(p.G), noise(p.B)}
+		}
+	}
+	return ppm, want
+}
+
+func meanError(ppm *PPM, want func(x int) Pixel) float64 {
+	total := 0
+	abs := func(a, b uint8) int { return max(int(a)-int(b), int(b)-int(a)) }
+	for y := range ppm.data {
+		for x, p := range ppm.data[y] {
+			w := want(x)
+			total += abs(p.R, w.R) + abs(p.G, w.G) + abs(p.B, w.B)
+		}
+	}
+	return float64(total) / float64(3*ppm.width*ppm.height)
+}
+
+func TestPPMBilateral(t *testing.T) {
+	ppm, want := noisyEdgePPM()
+	before := meanError(ppm, want)
+	ppm.Bilateral(2, 30)
+	if after := meanError(ppm, want); after >= before/2 {
+		t.Fatalf("Bilateral should reduce the noise: %.2f -> %.2f", before, after)
+	}
+	if p := ppm.data[6][9]; p.B > 60 || p.R < 180 {
+		t.Fatalf("Bilateral should not bleed across the edge: %v", p)
+	}
+}
+
+func TestPPMNonLocalMeans(t *testing.T) {
+	ppm, want := noisyEdgePPM()
+	before := meanError(ppm, want)
+	ppm.NonLocalMeans(DefaultNonLocalMeansOptions())
+	if after := meanError(ppm, want); after >= before/2 {
+		t.Fatalf("NonLocalMeans should reduce the noise: %.2f -> %.2f", before, after)
+	}
+	if p := ppm.data[6][10]; p.R > 60 || p.B < 180 {
+		t.Fatalf("NonLocalMeans should not bleed across the edge: %v", p)
+	}
+}
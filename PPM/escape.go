@@ -0,0 +1,131 @@
+package Netpbm
+
+import (
+	"math"
+	"math/cmplx"
+
+	"github.com/dada416-lebg/Netpbm/internal/raster"
+)
+
+// Palette associe une couleur à une valeur t comprise entre 0 et 1.
+type Palette func(t float64) Pixel
+
+// GradientPalette crée une palette à partir d'arrêts de couleur.
+func GradientPalette(stops ...ColorStop) Palette {
+	stops = sortStops(stops)
+	return func(t float64) Pixel {
+		return colorAtStops(stops, t)
+	}
+}
+
+// DefaultPalette est la palette utilisée quand aucune n'est fournie : du bleu nuit au blanc en passant par l'orange.
+func DefaultPalette() Palette {
+	return GradientPalette(
+		ColorStop{0, Pixel{0, 7, 100}},
+		ColorStop{0.16, Pixel{32, 107, 203}},
+		ColorStop{0.42, Pixel{237, 255, 255}},
+		ColorStop{0.64, Pixel{255, 170, 0}},
+		ColorStop{0.86, Pixel{0, 2, 0}},
+		ColorStop{1, Pixel{0, 7, 100}},
+	)
+}
+
+// Viewport est la zone du plan complexe affichée dans l'image.
+// Re va de gauche à droite, Im de bas en haut.
+type Viewport struct {
+	MinRe, MaxRe float64
+	MinIm, MaxIm float64
+}
+
+// FractalOptions regroupe les paramètres de rendu des fractales à temps d'échappement.
+type FractalOptions struct {
+	Viewport      Viewport
+	MaxIterations int
+	// Smooth active la coloration continue, qui supprime les bandes entre les nombres d'itérations.
+	Smooth bool
+	// Palette colore les points qui s'échappent ; DefaultPalette est utilisée si elle est nil.
+	Palette Palette
+	// InsideColor est la couleur des points qui ne s'échappent pas.
+	InsideColor Pixel
+}
+
+// DefaultMandelbrotOptions renvoie des paramètres qui montrent l'ensemble de Mandelbrot en entier.
+func DefaultMandelbrotOptions() FractalOptions {
+	return FractalOptions{
+		Viewport:      Viewport{-2.5, 1, -1.25, 1.25},
+		MaxIterations: 256,
+		Smooth:        true,
+	}
+}
+
+// DefaultJuliaOptions renvoie des paramètres qui montrent un ensemble de Julia en entier.
+func DefaultJuliaOptions() FractalOptions {
+	return FractalOptions{
+		Viewport:      Viewport{-1.75, 1.75, -1.25, 1.25},
+		MaxIterations: 256,
+		Smooth:        true,
+	}
+}
+
+// escapeRadius est grand pour que la coloration continue soit précise.
+const escapeRadius = 256.0
+
+// DrawMandelbrot dessine l'ensemble de Mandelbrot, z ← z² + c avec z₀ = 0 et c le point du pixel.
+func (ppm *PPM) DrawMandelbrot(options FractalOptions) {
+	ppm.drawEscapeTime(options, func(p complex128) (complex128, complex128) {
+		return 0, p
+	})
+}
+
+// DrawJulia dessine l'ensemble de Julia de paramètre c, z ← z² + c avec z₀ le point du pixel.
+func (ppm *PPM) DrawJulia(c complex128, options FractalOptions) {
+	ppm.drawEscapeTime(options, func(p complex128) (complex128, complex128) {
+		return p, c
+	})
+}
+
+// drawEscapeTime colore chaque pixel selon le temps d'échappement de la suite définie par start.
+// Les lignes sont réparties entre plusieurs goroutines.
+func (ppm *PPM) drawEscapeTime(options FractalOptions, start func(p complex128) (z, c complex128)) {
+	palette := options.Palette
+	if palette == nil {
+		palette = DefaultPalette()
+	}
+	view := options.Viewport
+	dx := (view.MaxRe - view.MinRe) / float64(ppm.width)
+	dy := (view.MaxIm - view.MinIm) / float64(ppm.height)
+
+	raster.ParallelRows(ppm.height, func(y int) {
+		im := view.MaxIm - (float64(y)+0.5)*dy
+		for x := 0; x < ppm.width; x++ {
+			re := view.MinRe + (float64(x)+0.5)*dx
+			z, c := start(complex(re, im))
+			t, inside := escapeTime(z, c, options.MaxIterations, options.Smooth)
+			if inside {
+				ppm.data[y][x] = options.InsideColor
+			} else {
+				ppm.data[y][x] = palette(t)
+			}
+		}
+	})
+}
+
+// escapeTime itère z ← z² + c et renvoie le temps d'échappement ramené dans [0, 1],
+// ou inside à true si la suite ne s'échappe pas en maxIterations itérations.
+func escapeTime(z, c complex128, maxIterations int, smooth bool) (t float64, inside bool) {
+	if maxIterations <= 0 {
+		return 0, true
+	}
+	for i := 0; i < maxIterations; i++ {
+		if real(z)*real(z)+imag(z)*imag(z) > escapeRadius*escapeRadius {
+			mu := float64(i)
+			if smooth {
+				// Nombre d'itérations fractionnaire : i + 1 - log₂(log|z|)
+				mu = float64(i) + 1 - math.Log2(math.Log(cmplx.Abs(z)))
+			}
+			return clamp01(mu / float64(maxIterations)), false
+		}
+		z = z*z + c
+	}
+	return 0, true
+}
@@ -0,0 +1,65 @@
+package Netpbm
+
+import "testing"
+
+func TestPPMDrawMandelbrot(t *testing.T) {
+	inside := Pixel{0, 0, 0}
+	ppm := newBlankPPM(70, 50)
+	options := DefaultMandelbrotOptions()
+	options.InsideColor = inside
+	ppm.DrawMandelbrot(options)
+
+	// Le pixel (50, 25) contient c ≈ 0, qui appartient à l'ensemble ; le coin supérieur gauche s'échappe
+	if ppm.data[25][50] != inside {
+		t.Errorf("Origin should be inside the set, got %v", ppm.data[25][50])
+	}
+	if ppm.data[0][0] == inside {
+		t.Error("Corner should escape")
+	}
+
+	// Le rendu parallèle doit donner le même résultat qu'un calcul direct
+	palette := DefaultPalette()
+	for _, p := range []Point{{0, 0}, {10, 40}, {33, 12}, {69, 49}} {
+		re := -2.5 + (float64(p.X)+0.5)*3.5/70
+		im := 1.25 - (float64(p.Y)+0.5)*2.5/50
+		want := inside
+		if tm, in := escapeTime(0, complex(re, im), 256, true); !in {
+			want = palette(tm)
+		}
+		if ppm.data[p.Y][p.X] != want {
+			t.Errorf("Pixel %v wrong: wanted %v got %v", p, want, ppm.data[p.Y][p.X])
+		}
+	}
+}
+
+func TestPPMDrawJulia(t *testing.T) {
+	// Pour c = 0, l'ensemble de Julia rempli est le disque unité
+	ppm := newBlankPPM(40, 40)
+	options := FractalOptions{
+		Viewport:      Viewport{-2, 2, -2, 2},
+		MaxIterations: 50,
+		Palette:       func(t float64) Pixel { return Pixel{255, 0, 0} },
+		InsideColor:   Pixel{0, 0, 255},
+	}
+	ppm.DrawJulia(0, options)
+	if ppm.data[20][20] != options.InsideColor || ppm.data[20][2] != (Pixel{255, 0, 0}) {
+		t.Error("Julia set for c = 0 should be the unit disk")
+	}
+	// Aire du disque unité : π / 16 de l'image
+	if n := countPixels(ppm, options.InsideColor); n < 290 || n > 340 {
+		t.Errorf("Unit disk area wrong: %d pixels", n)
+	}
+}
+
+func TestEscapeTimeSmooth(t *testing.T) {
+	// La coloration continue varie sans sauts entre deux points voisins
+	a, _ := escapeTime(0, complex(-0.75, 0.1), 1000, true)
+	b, _ := escapeTime(0, complex(-0.7501, 0.1), 1000, true)
+	c, _ := escapeTime(0, complex(-0.75, 0.1), 1000, false)
+	if a <= 0 || a >= 1 || a-b > 0.01 || b-a > 0.01 {
+		t.Errorf("Smooth escape time wrong: %f %f", a, b)
+	}
+	if c*1000 != float64(int(c*1000)) {
+		t.Errorf("Discrete escape time should be an integer count: %f", c*1000)
+	}
+}
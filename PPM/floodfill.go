@@ -0,0 +1,57 @@
+package Netpbm
+
+import "github.com/dada416-lebg/Netpbm/internal/raster"
+
+// Connectivity définit le voisinage utilisé pour relier les pixels entre eux.
+type Connectivity int
+
+const (
+	// Connect4 relie un pixel à ses voisins horizontaux et verticaux.
+	Connect4 Connectivity = 4
+	// Connect8 relie aussi un pixel à ses voisins en diagonale.
+	Connect8 Connectivity = 8
+)
+
+// FloodFill remplit avec color la zone connexe contenant p dont les pixels diffèrent de la couleur de p
+// d'au plus tolerance sur chaque composante.
+// Un point de départ hors de l'image la laisse inchangée.
+func (ppm *PPM) FloodFill(p Point, color Pixel, tolerance int, connectivity Connectivity) {
+	if p.X < 0 || p.X >= ppm.width || p.Y < 0 || p.Y >= ppm.height {
+		return
+	}
+	seed := ppm.data[p.Y][p.X]
+	raster.ScanlineFill(ppm.width, ppm.height, p.X, p.Y, connectivity == Connect8, func(x, y int) bool {
+		return colorDistance(ppm.data[y][x], seed) <= tolerance
+	}, func(x, y int) {
+		ppm.data[y][x] = color
+	})
+}
+
+// BoundaryFill remplit avec color la zone connexe contenant p délimitée par les pixels de couleur boundary.
+// Un point de départ hors de l'image la laisse inchangée.
+func (ppm *PPM) BoundaryFill(p Point, color, boundary Pixel, connectivity Connectivity) {
+	raster.ScanlineFill(ppm.width, ppm.height, p.X, p.Y, connectivity == Connect8, func(x, y int) bool {
+		return ppm.data[y][x] != boundary
+	}, func(x, y int) {
+		ppm.data[y][x] = color
+	})
+}
+
+// colorDistance renvoie le plus grand écart entre les composantes de deux couleurs.
+func colorDistance(a, b Pixel) int {
+	d := absInt(int(a.R) - int(b.R))
+	if g := absInt(int(a.G) - int(b.G)); g > d {
+		d = g
+	}
+	if bl := absInt(int(a.B) - int(b.B)); bl > d {
+		d = bl
+	}
+	return d
+}
+
+func absInt(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
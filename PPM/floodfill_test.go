@@ -0,0 +1,84 @@
+package Netpbm
+
+import "testing"
+
+func TestPPMFloodFillConnectivity(t *testing.T) {
+	black := Pixel{0, 0, 0}
+	red := Pixel{255, 0, 0}
+
+	// Une diagonale noire sépare les pixels blancs en deux zones seulement en 4-connexité
+	ppm := newBlankPPM(10, 10)
+	ppm.DrawLine(Point{X: 0, Y: 9}, Point{X: 9, Y: 0}, black)
+	ppm.FloodFill(Point{X: 0, Y: 0}, red, 0, Connect4)
+	if countPixels(ppm, red) != 45 {
+		t.Errorf("4-connected fill leaked through the diagonal: %d", countPixels(ppm, red))
+	}
+
+	ppm = newBlankPPM(10, 10)
+	ppm.DrawLine(Point{X: 0, Y: 9}, Point{X: 9, Y: 0}, black)
+	ppm.FloodFill(Point{X: 0, Y: 0}, red, 0, Connect8)
+	if countPixels(ppm, red) != 90 {
+		t.Errorf("8-connected fill should cross the diagonal: %d", countPixels(ppm, red))
+	}
+
+	// La ligne elle-même est 8-connexe mais pas 4-connexe
+	ppm.FloodFill(Point{X: 0, Y: 9}, red, 0, Connect4)
+	if countPixels(ppm, red) != 91 {
+		t.Errorf("4-connected fill of the line wrong: %d", countPixels(ppm, red))
+	}
+}
+
+func TestPPMFloodFillTolerance(t *testing.T) {
+	ppm := newBlankPPM(10, 1)
+	for x := 0; x < 10; x++ {
+		v := uint8(250 - 10*x)
+		ppm.data[0][x] = Pixel{v, v, v}
+	}
+	red := Pixel{255, 0, 0}
+	ppm.FloodFill(Point{X: 0, Y: 0}, red, 35, Connect4)
+	if countPixels(ppm, red) != 4 {
+		t.Errorf("Tolerance not respected: %d", countPixels(ppm, red))
+	}
+}
+
+func TestPPMFloodFillLargeRegion(t *testing.T) {
+	ppm := newBlankPPM(1500, 1500)
+	red := Pixel{255, 0, 0}
+	// Un serpentin force de nombreux segments sans récursion
+	for y := 1; y < 1500; y += 2 {
+		if (y/2)%2 == 0 {
+			ppm.DrawLine(Point{X: 0, Y: y}, Point{X: 1498, Y: y}, Pixel{0, 0, 0})
+		} else {
+			ppm.DrawLine(Point{X: 1, Y: y}, Point{X: 1499, Y: y}, Pixel{0, 0, 0})
+		}
+	}
+	ppm.FloodFill(Point{X: 0, Y: 0}, red, 0, Connect4)
+	if ppm.data[1498][0] != red || ppm.data[0][1499] != red {
+		t.Error("Serpentine region not entirely filled")
+	}
+}
+
+func TestPPMBoundaryFill(t *testing.T) {
+	ppm := newBlankPPM(10, 10)
+	blue := Pixel{0, 0, 255}
+	red := Pixel{255, 0, 0}
+	ppm.data[5][5] = Pixel{10, 10, 10}
+	ppm.DrawRectangle(Point{X: 2, Y: 2}, 6, 1, blue)
+	ppm.DrawRectangle(Point{X: 2, Y: 7}, 6, 1, blue)
+	ppm.DrawRectangle(Point{X: 2, Y: 2}, 1, 6, blue)
+	ppm.DrawRectangle(Point{X: 7, Y: 2}, 1, 6, blue)
+	ppm.BoundaryFill(Point{X: 4, Y: 4}, red, blue, Connect4)
+	if countPixels(ppm, red) != 16 {
+		t.Errorf("Boundary fill wrong: %d", countPixels(ppm, red))
+	}
+}
+
+func TestPPMFloodFillOutOfBounds(t *testing.T) {
+	ppm := newBlankPPM(4, 4)
+	red := Pixel{255, 0, 0}
+	ppm.FloodFill(Point{X: 0, Y: -1}, red, 0, Connect4)
+	ppm.BoundaryFill(Point{X: 0, Y: 4}, red, Pixel{0, 0, 255}, Connect8)
+	if countPixels(ppm, red) != 0 {
+		t.Error("Fill from a seed outside the image should do nothing")
+	}
+}
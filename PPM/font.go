@@ -0,0 +1,60 @@
+package Netpbm
+
+import (
+	"io"
+
+	"github.com/dada416-lebg/Netpbm/internal/font"
+)
+
+// Glyph représente le dessin bitmap d'un caractère.
+// Comme dans le format BDF, XOffset et YOffset placent le coin inférieur gauche du bitmap
+// par rapport à l'origine du caractère, située sur la ligne de base.
+type Glyph = font.Glyph
+
+// Font est une police bitmap. Les polices sont communes aux trois formats.
+type Font = font.Font
+
+// TextAlign définit l'alignement horizontal des lignes de texte.
+type TextAlign = font.TextAlign
+
+const (
+	AlignLeft   = font.AlignLeft
+	AlignCenter = font.AlignCenter
+	AlignRight  = font.AlignRight
+)
+
+// TextOptions regroupe les paramètres de rendu du texte.
+type TextOptions = font.TextOptions
+
+// MeasureText renvoie la largeur et la hauteur, en pixels, du texte rendu avec les options données.
+func MeasureText(text string, opts TextOptions) (int, int) {
+	return font.MeasureText(text, opts)
+}
+
+// LoadBDF charge une police au format BDF (Glyph Bitmap Distribution Format) depuis un fichier.
+func LoadBDF(filename string) (*Font, error) {
+	return font.LoadBDF(filename)
+}
+
+// ParseBDF lit une police au format BDF.
+func ParseBDF(r io.Reader) (*Font, error) {
+	return font.ParseBDF(r)
+}
+
+// DefaultFont renvoie la police bitmap intégrée : 5x7 pixels avec jambages, couvrant l'ASCII imprimable.
+func DefaultFont() *Font {
+	return font.DefaultFont()
+}
+
+// DrawText écrit le texte dans l'image PPM avec la police intégrée, à partir du coin supérieur gauche p.
+// Les caractères '\n' provoquent un retour à la ligne.
+func (ppm *PPM) DrawText(p Point, text string, color Pixel) {
+	ppm.DrawTextWithOptions(p, text, color, TextOptions{})
+}
+
+// DrawTextWithOptions écrit le texte dans l'image PPM avec la police, l'alignement et l'échelle donnés.
+func (ppm *PPM) DrawTextWithOptions(p Point, text string, color Pixel, opts TextOptions) {
+	font.Render(p.X, p.Y, text, opts, func(x, y int) {
+		ppm.plot(x, y, color)
+	})
+}
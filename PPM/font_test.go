@@ -0,0 +1,130 @@
+package Netpbm
+
+import (
+	"strings"
+	"testing"
+)
+
+const testBDF = `STARTFONT 2.1
+FONT -test-font
+SIZE 4 75 75
+FONTBOUNDINGBOX 3 4 0 -1
+STARTPROPERTIES 2
+FONT_ASCENT 3
+FONT_DESCENT 1
+ENDPROPERTIES
+CHARS 2
+STARTCHAR A
+ENCODING 65
+DWIDTH 4 0
+BBX 3 3 0 0
+BITMAP
+40
+A0
+E0
+ENDCHAR
+STARTCHAR question
+ENCODING 63
+DWIDTH 2 0
+BBX 1 4 0 -1
+BITMAP
+80
+80
+00
+80
+ENDCHAR
+ENDFONT
+`
+
+func TestPPMDrawText(t *testing.T) {
+	ppm := newBlankPPM(20, 12)
+	black := Pixel{0, 0, 0}
+	ppm.DrawText(Point{X: 1, Y: 2}, "T", black)
+
+	// Le T de la police intégrée : une barre de 5 pixels avec empattements puis un fût central
+	for x := 1; x <= 5; x++ {
+		if ppm.data[2][x] != black {
+			t.Errorf("Pixel at (%d, 2) not drawn", x)
+		}
+	}
+	for y := 3; y <= 8; y++ {
+		if ppm.data[y][3] != black {
+			t.Errorf("Pixel at (3, %d) not drawn", y)
+		}
+	}
+	if countPixels(ppm, black) != 13 {
+		t.Errorf("Wrong number of pixels drawn: %d", countPixels(ppm, black))
+	}
+}
+
+func TestMeasureText(t *testing.T) {
+	width, height := MeasureText("ab\nabcd", TextOptions{})
+	if width != 24 || height != 16 {
+		t.Errorf("Wrong text extents: %dx%d", width, height)
+	}
+	width, height = MeasureText("ab", TextOptions{Scale: 2, LineSpacing: 3})
+	if width != 24 || height != 16 {
+		t.Errorf("Wrong scaled text extents: %dx%d", width, height)
+	}
+}
+
+func TestPPMDrawTextAlign(t *testing.T) {
+	black := Pixel{0, 0, 0}
+	left := newBlankPPM(30, 10)
+	left.DrawTextWithOptions(Point{X: 3, Y: 0}, "I", black, TextOptions{})
+	right := newBlankPPM(30, 10)
+	right.DrawTextWithOptions(Point{X: 9, Y: 0}, "I", black, TextOptions{Align: AlignRight})
+	center := newBlankPPM(30, 10)
+	center.DrawTextWithOptions(Point{X: 6, Y: 0}, "I", black, TextOptions{Align: AlignCenter})
+
+	for y := 0; y < 10; y++ {
+		for x := 0; x < 30; x++ {
+			if left.data[y][x] != right.data[y][x] || left.data[y][x] != center.data[y][x] {
+				t.Fatalf("Aligned text differs at (%d, %d)", x, y)
+			}
+		}
+	}
+}
+
+func TestParseBDF(t *testing.T) {
+	font, err := ParseBDF(strings.NewReader(testBDF))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if font.Ascent != 3 || font.Descent != 1 || font.Default != '?' {
+		t.Errorf("Font metrics not read correctly: %+v", font)
+	}
+	glyph := font.Glyphs['A']
+	if glyph == nil || glyph.Advance != 4 || len(glyph.Bitmap) != 3 {
+		t.Fatal("Glyph A not read correctly")
+	}
+	want := [][]bool{{false, true, false}, {true, false, true}, {true, true, true}}
+	for y := range want {
+		for x := range want[y] {
+			if glyph.Bitmap[y][x] != want[y][x] {
+				t.Errorf("Bitmap of A wrong at (%d, %d)", x, y)
+			}
+		}
+	}
+
+	// Les caractères absents utilisent le caractère par défaut
+	width, _ := MeasureText("AzA", TextOptions{Font: font})
+	if width != 10 {
+		t.Errorf("Wrong width with default char: %d", width)
+	}
+
+	ppm := newBlankPPM(10, 5)
+	black := Pixel{0, 0, 0}
+	ppm.DrawTextWithOptions(Point{X: 0, Y: 0}, "A?", black, TextOptions{Font: font})
+	if ppm.data[0][1] != black || ppm.data[2][2] != black || ppm.data[3][4] != black || ppm.data[2][4] == black {
+		t.Error("BDF text not drawn correctly")
+	}
+}
+
+func TestParseBDFErrors(t *testing.T) {
+	for _, data := range []string{"", "STARTFONT 2.1\nSTARTCHAR A\nBBX 1 x 0 0\n", "STARTCHAR A\nBITMAP\n80\n"} {
+		if _, err := ParseBDF(strings.NewReader(data)); err == nil {
+			t.Errorf("Expected an error for %q", data)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package Netpbm
+
+import (
+	"math"
+	"testing"
+)
+
+func TestKochSnowflakeGeometry(t *testing.T) {
+	for n := 0; n <= 3; n++ {
+		points := kochSnowflake(n, Point{10, 80}, 81)
+		if want := 3 * int(math.Pow(4, float64(n))); len(points) != want {
+			t.Fatalf("Order %d: wanted %d vertices got %d", n, want, len(points))
+		}
+		side := 81 / math.Pow(3, float64(n))
+		for i := range points {
+			next := points[(i+1)%len(points)]
+			if d := math.Hypot(next.X-points[i].X, next.Y-points[i].Y); math.Abs(d-side) > 1e-9 {
+				t.Fatalf("Order %d: segment %d has length %f, wanted %f", n, i, d, side)
+			}
+		}
+	}
+
+	// Les bosses de la base doivent pointer vers le bas, hors du triangle
+	below := false
+	for _, p := range kochSnowflake(1, Point{10, 80}, 81) {
+		if p.Y > 80 {
+			below = true
+		}
+	}
+	if !below {
+		t.Error("Koch bumps point inward")
+	}
+}
+
+func TestPPMDrawFilledKochSnowflake(t *testing.T) {
+	ppm := newBlankPPM(120, 120)
+	color := Pixel{0, 0, 255}
+	ppm.DrawFilledKochSnowflake(4, Point{15, 90}, 90, color)
+
+	// L'aire du flocon vaut 8/5 de celle du triangle de départ
+	want := 8.0 / 5.0 * math.Sqrt(3) / 4 * 90 * 90
+	if got := float64(countPixels(ppm, color)); math.Abs(got-want)/want > 0.03 {
+		t.Errorf("Snowflake area wrong: wanted about %f got %f", want, got)
+	}
+
+	outline := newBlankPPM(120, 120)
+	outline.DrawKochSnowflake(2, Point{15, 90}, 90, color)
+	if outline.data[60][60] != (Pixel{255, 255, 255}) || countPixels(outline, color) == 0 {
+		t.Error("Outlined snowflake should only draw the border")
+	}
+}
+
+func TestSierpinskiTriangle(t *testing.T) {
+	if got := len(sierpinskiTriangles(4, Point{0, 100}, 100)); got != 81 {
+		t.Errorf("Wanted 81 triangles got %d", got)
+	}
+
+	ppm := newBlankPPM(130, 130)
+	color := Pixel{0, 128, 0}
+	ppm.DrawFilledSierpinskiTriangle(3, Point{1, 120}, 128, color)
+	want := math.Pow(0.75, 3) * math.Sqrt(3) / 4 * 128 * 128
+	if got := float64(countPixels(ppm, color)); math.Abs(got-want)/want > 0.05 {
+		t.Errorf("Sierpinski area wrong: wanted about %f got %f", want, got)
+	}
+	// Le centre du triangle central retiré reste vide
+	cy := 120 - int(128*math.Sqrt(3)/4/2)
+	if ppm.data[cy][65] == color {
+		t.Error("Central triangle should be empty")
+	}
+
+	outline := newBlankPPM(130, 130)
+	outline.DrawSierpinskiTriangle(2, Point{1, 120}, 128, color)
+	if outline.data[120][1] != color || outline.data[120][65] != color || outline.data[cy][65] == color {
+		t.Error("Sierpinski outline wrong")
+	}
+}
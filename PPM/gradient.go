@@ -0,0 +1,211 @@
+package Netpbm
+
+import (
+	"math"
+	"sort"
+
+	"github.com/dada416-lebg/Netpbm/internal/raster"
+)
+
+// Paint donne la couleur à utiliser en chaque point d'une forme remplie.
+type Paint interface {
+	ColorAt(x, y float64) Pixel
+}
+
+// SolidPaint est une peinture d'une seule couleur.
+type SolidPaint Pixel
+
+// ColorAt renvoie toujours la même couleur.
+func (s SolidPaint) ColorAt(x, y float64) Pixel {
+	return Pixel(s)
+}
+
+// ColorStop est une couleur placée à une position Offset, entre 0 et 1, le long d'un dégradé.
+type ColorStop struct {
+	Offset float64
+	Color  Pixel
+}
+
+// SpreadMode définit la couleur d'un dégradé en dehors de l'intervalle [0, 1].
+type SpreadMode int
+
+const (
+	// SpreadPad prolonge les couleurs des extrémités.
+	SpreadPad SpreadMode = iota
+	// SpreadRepeat répète le dégradé.
+	SpreadRepeat
+	// SpreadReflect répète le dégradé en alternant son sens.
+	SpreadReflect
+)
+
+// LinearGradient est un dégradé le long du segment allant de Start à End.
+type LinearGradient struct {
+	Start, End PointF
+	Stops      []ColorStop
+	Spread     SpreadMode
+}
+
+// RadialGradient est un dégradé circulaire allant du centre (offset 0) jusqu'au cercle de rayon Radius (offset 1).
+type RadialGradient struct {
+	Center PointF
+	Radius float64
+	Stops  []ColorStop
+	Spread SpreadMode
+}
+
+// ConicGradient est un dégradé qui tourne autour de Center dans le sens des aiguilles d'une montre,
+// en partant de l'angle Angle exprimé en degrés (0 pointe vers la droite).
+type ConicGradient struct {
+	Center PointF
+	Angle  float64
+	Stops  []ColorStop
+}
+
+// NewLinearGradient crée un dégradé linéaire ; les arrêts sont triés par position.
+func NewLinearGradient(start, end PointF, stops ...ColorStop) *LinearGradient {
+	return &LinearGradient{Start: start, End: end, Stops: sortStops(stops)}
+}
+
+// NewRadialGradient crée un dégradé radial ; les arrêts sont triés par position.
+func NewRadialGradient(center PointF, radius float64, stops ...ColorStop) *RadialGradient {
+	return &RadialGradient{Center: center, Radius: radius, Stops: sortStops(stops)}
+}
+
+// NewConicGradient crée un dégradé conique ; les arrêts sont triés par position.
+func NewConicGradient(center PointF, angle float64, stops ...ColorStop) *ConicGradient {
+	return &ConicGradient{Center: center, Angle: angle, Stops: sortStops(stops)}
+}
+
+// ColorAt projette le point sur l'axe du dégradé.
+func (g *LinearGradient) ColorAt(x, y float64) Pixel {
+	dx, dy := g.End.X-g.Start.X, g.End.Y-g.Start.Y
+	length := dx*dx + dy*dy
+	if length == 0 {
+		return colorAtStops(g.Stops, 0)
+	}
+	t := ((x-g.Start.X)*dx + (y-g.Start.Y)*dy) / length
+	return colorAtStops(g.Stops, spread(t, g.Spread))
+}
+
+// ColorAt utilise la distance du point au centre.
+func (g *RadialGradient) ColorAt(x, y float64) Pixel {
+	if g.Radius <= 0 {
+		return colorAtStops(g.Stops, 1)
+	}
+	t := math.Hypot(x-g.Center.X, y-g.Center.Y) / g.Radius
+	return colorAtStops(g.Stops, spread(t, g.Spread))
+}
+
+// ColorAt utilise l'angle du point autour du centre.
+func (g *ConicGradient) ColorAt(x, y float64) Pixel {
+	// L'axe des y de l'image pointe vers le bas, atan2 tourne donc dans le sens des aiguilles d'une montre
+	angle := math.Atan2(y-g.Center.Y, x-g.Center.X) - g.Angle*math.Pi/180
+	t := angle / (2 * math.Pi)
+	return colorAtStops(g.Stops, t-math.Floor(t))
+}
+
+// sortStops renvoie une copie des arrêts triés par position.
+func sortStops(stops []ColorStop) []ColorStop {
+	sorted := append([]ColorStop(nil), stops...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Offset < sorted[j].Offset
+	})
+	return sorted
+}
+
+// spread ramène t dans [0, 1] selon le mode choisi.
+func spread(t float64, mode SpreadMode) float64 {
+	switch mode {
+	case SpreadRepeat:
+		return t - math.Floor(t)
+	case SpreadReflect:
+		t = math.Mod(math.Abs(t), 2)
+		if t > 1 {
+			t = 2 - t
+		}
+		return t
+	}
+	return clamp01(t)
+}
+
+// colorAtStops interpole la couleur à la position t entre les deux arrêts qui l'encadrent.
+// Les arrêts doivent être triés par position.
+func colorAtStops(stops []ColorStop, t float64) Pixel {
+	if len(stops) == 0 {
+		return Pixel{}
+	}
+	if t <= stops[0].Offset {
+		return stops[0].Color
+	}
+	for i := 1; i < len(stops); i++ {
+		if t <= stops[i].Offset {
+			prev, next := stops[i-1], stops[i]
+			if next.Offset == prev.Offset {
+				return next.Color
+			}
+			return interpolateColor(prev.Color, next.Color, (t-prev.Offset)/(next.Offset-prev.Offset))
+		}
+	}
+	return stops[len(stops)-1].Color
+}
+
+// fillSpansWithPaint retourne une fonction de remplissage de segments qui évalue la peinture au centre de chaque pixel.
+func (ppm *PPM) fillSpansWithPaint(paint Paint) func(y, x0, x1 int) {
+	return func(y, x0, x1 int) {
+		for x := x0; x < x1; x++ {
+			ppm.data[y][x] = paint.ColorAt(float64(x)+0.5, float64(y)+0.5)
+		}
+	}
+}
+
+// FillRectangleWithPaint remplit le rectangle de coin supérieur gauche p1 avec la peinture donnée.
+// La partie du rectangle située hors de l'image est ignorée.
+func (ppm *PPM) FillRectangleWithPaint(p1 Point, width, height int, paint Paint) {
+	fill := ppm.fillSpansWithPaint(paint)
+	x0, x1 := max(p1.X, 0), min(p1.X+width, ppm.width)
+	for y := max(p1.Y, 0); y < min(p1.Y+height, ppm.height); y++ {
+		if x0 < x1 {
+			fill(y, x0, x1)
+		}
+	}
+}
+
+// FillCircleWithPaint remplit le disque de centre center et de rayon radius avec la peinture donnée.
+func (ppm *PPM) FillCircleWithPaint(center Point, radius int, paint Paint) {
+	if radius <= 0 {
+		return
+	}
+	r2 := radius * radius
+	fill := ppm.fillSpansWithPaint(paint)
+	for dy := -radius; dy <= radius; dy++ {
+		y := center.Y + dy
+		if y < 0 || y >= ppm.height {
+			continue
+		}
+		// Demi-largeur de la ligne du disque
+		dx := int(math.Sqrt(float64(r2 - dy*dy)))
+		x0, x1 := max(center.X-dx, 0), min(center.X+dx+1, ppm.width)
+		if x0 < x1 {
+			fill(y, x0, x1)
+		}
+	}
+}
+
+// FillPolygonWithPaint remplit le polygone dont les sommets sont points avec la peinture donnée.
+// Comme pour les chemins, un pixel est rempli si son centre est à l'intérieur du polygone.
+func (ppm *PPM) FillPolygonWithPaint(points []Point, paint Paint) {
+	if len(points) < 3 {
+		return
+	}
+	polygon := make([]PointF, len(points)+1)
+	for i, p := range points {
+		polygon[i] = PointF{X: float64(p.X), Y: float64(p.Y)}
+	}
+	polygon[len(points)] = polygon[0]
+	raster.FillPolygons([][]PointF{polygon}, FillNonZero, ppm.width, ppm.height, ppm.fillSpansWithPaint(paint))
+}
+
+// FillPathWithPaint remplit le chemin avec la peinture donnée, selon sa règle de remplissage.
+func (ppm *PPM) FillPathWithPaint(path *Path, paint Paint) {
+	raster.FillPolygons(path.Polylines(), path.FillRule, ppm.width, ppm.height, ppm.fillSpansWithPaint(paint))
+}
@@ -0,0 +1,95 @@
+package Netpbm
+
+import "testing"
+
+var (
+	black = Pixel{0, 0, 0}
+	white = Pixel{255, 255, 255}
+	red   = Pixel{255, 0, 0}
+)
+
+func TestLinearGradientStops(t *testing.T) {
+	g := NewLinearGradient(PointF{X: 0, Y: 0}, PointF{X: 100, Y: 0},
+		ColorStop{1, white}, ColorStop{0, black}, ColorStop{0.5, red})
+
+	tests := []struct {
+		x    float64
+		want Pixel
+	}{
+		{-10, black},
+		{0, black},
+		{25, Pixel{127, 0, 0}},
+		{50, red},
+		{100, white},
+		{150, white},
+	}
+	for _, test := range tests {
+		if got := g.ColorAt(test.x, 42); got != test.want {
+			t.Errorf("Color at x=%f wrong: wanted %v got %v", test.x, test.want, got)
+		}
+	}
+}
+
+func TestGradientSpread(t *testing.T) {
+	g := NewLinearGradient(PointF{X: 0, Y: 0}, PointF{X: 10, Y: 0}, ColorStop{0, black}, ColorStop{1, white})
+	g.Spread = SpreadRepeat
+	if got := g.ColorAt(12.5, 0); got != g.ColorAt(2.5, 0) {
+		t.Errorf("Repeat spread wrong: %v", got)
+	}
+	g.Spread = SpreadReflect
+	if got := g.ColorAt(12.5, 0); got != g.ColorAt(7.5, 0) {
+		t.Errorf("Reflect spread wrong: %v", got)
+	}
+}
+
+func TestRadialAndConicGradient(t *testing.T) {
+	radial := NewRadialGradient(PointF{X: 10, Y: 10}, 10, ColorStop{0, white}, ColorStop{1, black})
+	if radial.ColorAt(10, 10) != white || radial.ColorAt(10, 25) != black {
+		t.Error("Radial gradient wrong at center or outside")
+	}
+	if radial.ColorAt(15, 10) != radial.ColorAt(10, 5) {
+		t.Error("Radial gradient should depend only on distance")
+	}
+
+	conic := NewConicGradient(PointF{X: 0, Y: 0}, 0, ColorStop{0, black}, ColorStop{1, white})
+	// Un quart de tour dans le sens des aiguilles d'une montre pointe vers le bas
+	if got := conic.ColorAt(0, 10); got != interpolateColor(black, white, 0.25) {
+		t.Errorf("Conic gradient wrong: %v", got)
+	}
+}
+
+func TestPPMFillWithPaint(t *testing.T) {
+	g := NewLinearGradient(PointF{X: 0, Y: 0}, PointF{X: 10, Y: 0}, ColorStop{0, black}, ColorStop{1, red})
+
+	ppm := newBlankPPM(10, 10)
+	ppm.FillRectangleWithPaint(Point{-5, 2}, 20, 3, g)
+	if ppm.data[2][0] != g.ColorAt(0.5, 2.5) || ppm.data[4][9] != g.ColorAt(9.5, 4.5) {
+		t.Errorf("Rectangle paint wrong: %v %v", ppm.data[2][0], ppm.data[4][9])
+	}
+	if ppm.data[1][0] != white || ppm.data[5][0] != white {
+		t.Error("Rectangle paint overflowed")
+	}
+
+	ppm = newBlankPPM(10, 10)
+	ppm.FillCircleWithPaint(Point{5, 5}, 3, SolidPaint(red))
+	if got := countPixels(ppm, red); got != 29 {
+		t.Errorf("Circle paint wrong: wanted 29 pixels got %d", got)
+	}
+
+	ppm = newBlankPPM(10, 10)
+	ppm.FillPolygonWithPaint([]Point{{1, 1}, {8, 1}, {8, 8}, {1, 8}}, g)
+	if countPixels(ppm, white) != 100-49 {
+		t.Errorf("Polygon paint wrong: %d white pixels left", countPixels(ppm, white))
+	}
+
+	ppm = newBlankPPM(10, 10)
+	path := NewPath()
+	path.MoveTo(0, 0)
+	path.LineTo(10, 0)
+	path.LineTo(10, 10)
+	path.Close()
+	ppm.FillPathWithPaint(path, g)
+	if ppm.data[0][9] != g.ColorAt(9.5, 0.5) || ppm.data[9][0] != white {
+		t.Error("Path paint wrong")
+	}
+}
@@ -0,0 +1,197 @@
+package Netpbm
+
+import (
+	"math"
+	"strings"
+)
+
+// LSystem est un système de Lindenmayer interprété par une tortue.
+//
+// Symboles de la tortue :
+//   - les symboles de Draw (par défaut F et G) avancent d'un pas en traçant ;
+//   - les symboles de Move (par défaut f) avancent d'un pas sans tracer ;
+//   - + tourne à gauche et - tourne à droite de Angle degrés, | fait demi-tour ;
+//   - [ mémorise la position et la direction, ] revient à la dernière mémorisée.
+//
+// Les autres symboles ne servent qu'aux règles de production.
+type LSystem struct {
+	Axiom string
+	Rules map[rune]string
+	// Angle est l'angle de rotation en degrés.
+	Angle      float64
+	Iterations int
+	// StartAngle est la direction initiale en degrés : 0 vers la droite, 90 vers le haut.
+	StartAngle float64
+	Draw       string
+	Move       string
+}
+
+// Expand applique Iterations fois les règles de production à l'axiome.
+func (ls LSystem) Expand() string {
+	current := ls.Axiom
+	for i := 0; i < ls.Iterations; i++ {
+		var next strings.Builder
+		for _, symbol := range current {
+			if replacement, ok := ls.Rules[symbol]; ok {
+				next.WriteString(replacement)
+			} else {
+				next.WriteRune(symbol)
+			}
+		}
+		current = next.String()
+	}
+	return current
+}
+
+// turtleState est la position et la direction de la tortue.
+type turtleState struct {
+	position PointF
+	heading  float64
+}
+
+// Segments renvoie les segments tracés par la tortue, partie de l'origine avec un pas de 1.
+// Les coordonnées suivent celles de l'image : l'axe des y pointe vers le bas.
+func (ls LSystem) Segments() [][2]PointF {
+	draw, move := ls.Draw, ls.Move
+	if draw == "" {
+		draw = "FG"
+	}
+	if move == "" {
+		move = "f"
+	}
+
+	var segments [][2]PointF
+	var stack []turtleState
+	turtle := turtleState{heading: ls.StartAngle}
+	for _, symbol := range ls.Expand() {
+		switch {
+		case strings.ContainsRune(draw, symbol), strings.ContainsRune(move, symbol):
+			rad := turtle.heading * math.Pi / 180
+			next := PointF{X: turtle.position.X + math.Cos(rad), Y: turtle.position.Y - math.Sin(rad)}
+			if strings.ContainsRune(draw, symbol) {
+				segments = append(segments, [2]PointF{turtle.position, next})
+			}
+			turtle.position = next
+		case symbol == '+':
+			turtle.heading += ls.Angle
+		case symbol == '-':
+			turtle.heading -= ls.Angle
+		case symbol == '|':
+			turtle.heading += 180
+		case symbol == '[':
+			stack = append(stack, turtle)
+		case symbol == ']':
+			if len(stack) > 0 {
+				turtle = stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+			}
+		}
+	}
+	return segments
+}
+
+// DrawLSystem dessine le L-système avec la tortue partant de start et avançant de step pixels à chaque pas.
+func (ppm *PPM) DrawLSystem(ls LSystem, start Point, step float64, color Pixel) {
+	for _, segment := range ls.Segments() {
+		a := PointF{X: float64(start.X) + segment[0].X*step, Y: float64(start.Y) + segment[0].Y*step}
+		b := PointF{X: float64(start.X) + segment[1].X*step, Y: float64(start.Y) + segment[1].Y*step}
+		ppm.DrawLine(roundPoint(a), roundPoint(b), color)
+	}
+}
+
+// DrawLSystemFit dessine le L-système mis à l'échelle et centré pour occuper l'image, à margin pixels des bords.
+func (ppm *PPM) DrawLSystemFit(ls LSystem, margin int, color Pixel) {
+	segments := ls.Segments()
+	if len(segments) == 0 {
+		return
+	}
+
+	minX, minY := math.Inf(1), math.Inf(1)
+	maxX, maxY := math.Inf(-1), math.Inf(-1)
+	for _, segment := range segments {
+		for _, p := range segment {
+			minX, maxX = math.Min(minX, p.X), math.Max(maxX, p.X)
+			minY, maxY = math.Min(minY, p.Y), math.Max(maxY, p.Y)
+		}
+	}
+
+	// Les pixels extrêmes sont aux indices margin et size-1-margin
+	availableX := float64(ppm.width - 1 - 2*margin)
+	availableY := float64(ppm.height - 1 - 2*margin)
+	scale := math.Inf(1)
+	if maxX > minX {
+		scale = availableX / (maxX - minX)
+	}
+	if maxY > minY {
+		scale = math.Min(scale, availableY/(maxY-minY))
+	}
+	if math.IsInf(scale, 1) || scale <= 0 {
+		return
+	}
+
+	offsetX := float64(margin) + (availableX-(maxX-minX)*scale)/2 - minX*scale
+	offsetY := float64(margin) + (availableY-(maxY-minY)*scale)/2 - minY*scale
+	for _, segment := range segments {
+		a := PointF{X: offsetX + segment[0].X*scale, Y: offsetY + segment[0].Y*scale}
+		b := PointF{X: offsetX + segment[1].X*scale, Y: offsetY + segment[1].Y*scale}
+		ppm.DrawLine(roundPoint(a), roundPoint(b), color)
+	}
+}
+
+// KochCurveLSystem renvoie la courbe de Koch d'ordre n.
+func KochCurveLSystem(n int) LSystem {
+	return LSystem{
+		Axiom:      "F",
+		Rules:      map[rune]string{'F': "F+F--F+F"},
+		Angle:      60,
+		Iterations: n,
+	}
+}
+
+// KochSnowflakeLSystem renvoie le flocon de Koch d'ordre n.
+func KochSnowflakeLSystem(n int) LSystem {
+	ls := KochCurveLSystem(n)
+	ls.Axiom = "F--F--F"
+	return ls
+}
+
+// SierpinskiLSystem renvoie le triangle de Sierpinski d'ordre n.
+func SierpinskiLSystem(n int) LSystem {
+	return LSystem{
+		Axiom:      "F-G-G",
+		Rules:      map[rune]string{'F': "F-G+F+G-F", 'G': "GG"},
+		Angle:      120,
+		Iterations: n,
+	}
+}
+
+// DragonCurveLSystem renvoie la courbe du dragon d'ordre n.
+func DragonCurveLSystem(n int) LSystem {
+	return LSystem{
+		Axiom:      "FX",
+		Rules:      map[rune]string{'X': "X+YF+", 'Y': "-FX-Y"},
+		Angle:      90,
+		Iterations: n,
+	}
+}
+
+// HilbertCurveLSystem renvoie la courbe de Hilbert d'ordre n, qui parcourt une grille de 2^n × 2^n points.
+func HilbertCurveLSystem(n int) LSystem {
+	return LSystem{
+		Axiom:      "A",
+		Rules:      map[rune]string{'A': "+BF-AFA-FB+", 'B': "-AF+BFB+FA-"},
+		Angle:      90,
+		Iterations: n,
+	}
+}
+
+// PlantLSystem renvoie une plante fractale d'ordre n qui pousse vers le haut.
+func PlantLSystem(n int) LSystem {
+	return LSystem{
+		Axiom:      "X",
+		Rules:      map[rune]string{'X': "F+[[X]-X]-F[-FX]+X", 'F': "FF"},
+		Angle:      25,
+		Iterations: n,
+		StartAngle: 90,
+	}
+}
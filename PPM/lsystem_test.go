@@ -0,0 +1,89 @@
+package Netpbm
+
+import (
+	"math"
+	"testing"
+)
+
+func TestLSystemExpand(t *testing.T) {
+	ls := LSystem{Axiom: "A", Rules: map[rune]string{'A': "AB", 'B': "A"}, Iterations: 5}
+	// Les longueurs suivent la suite de Fibonacci
+	if got := ls.Expand(); got != "ABAABABAABAAB" {
+		t.Errorf("Expand wrong: %s", got)
+	}
+}
+
+func TestLSystemSegments(t *testing.T) {
+	if got := len(KochSnowflakeLSystem(3).Segments()); got != 3*64 {
+		t.Errorf("Koch snowflake: wanted %d segments got %d", 3*64, got)
+	}
+	if got := len(SierpinskiLSystem(3).Segments()); got != 81 {
+		t.Errorf("Sierpinski: wanted 81 segments got %d", got)
+	}
+
+	// La courbe de Hilbert passe une seule fois par chacun des 4^n points de la grille
+	segments := HilbertCurveLSystem(3).Segments()
+	if len(segments) != 63 {
+		t.Fatalf("Hilbert: wanted 63 segments got %d", len(segments))
+	}
+	visited := map[Point]bool{roundPoint(segments[0][0]): true}
+	for _, s := range segments {
+		visited[roundPoint(s[1])] = true
+	}
+	if len(visited) != 64 {
+		t.Errorf("Hilbert curve visited %d points instead of 64", len(visited))
+	}
+
+	// Le dragon d'ordre n a 2^n segments et ne repasse jamais sur un segment
+	dragon := DragonCurveLSystem(8).Segments()
+	edges := map[[2]Point]bool{}
+	for _, s := range dragon {
+		a, b := roundPoint(s[0]), roundPoint(s[1])
+		if b.X < a.X || (b.X == a.X && b.Y < a.Y) {
+			a, b = b, a
+		}
+		edges[[2]Point{a, b}] = true
+	}
+	if len(dragon) != 256 || len(edges) != len(dragon) {
+		t.Errorf("Dragon curve wrong: %d segments, %d distinct", len(dragon), len(edges))
+	}
+}
+
+func TestLSystemBrackets(t *testing.T) {
+	ls := LSystem{Axiom: "F[+F]F", Angle: 90, StartAngle: 90}
+	segments := ls.Segments()
+	want := [][2]PointF{
+		{{X: 0, Y: 0}, {X: 0, Y: -1}},
+		{{X: 0, Y: -1}, {X: -1, Y: -1}},
+		{{X: 0, Y: -1}, {X: 0, Y: -2}},
+	}
+	for i, s := range segments {
+		for j := range s {
+			if math.Abs(s[j].X-want[i][j].X) > 1e-9 || math.Abs(s[j].Y-want[i][j].Y) > 1e-9 {
+				t.Fatalf("Segment %d wrong: wanted %v got %v", i, want[i], s)
+			}
+		}
+	}
+}
+
+func TestPPMDrawLSystemFit(t *testing.T) {
+	ppm := newBlankPPM(50, 40)
+	color := Pixel{0, 100, 0}
+	ppm.DrawLSystemFit(PlantLSystem(4), 2, color)
+	if countPixels(ppm, color) == 0 {
+		t.Fatal("Nothing drawn")
+	}
+	for y := range ppm.data {
+		for x := range ppm.data[y] {
+			if ppm.data[y][x] == color && (x < 2 || x > 47 || y < 2 || y > 37) {
+				t.Fatalf("Pixel (%d, %d) drawn inside the margin", x, y)
+			}
+		}
+	}
+
+	ppm = newBlankPPM(20, 20)
+	ppm.DrawLSystem(LSystem{Axiom: "F+F+F+F", Angle: 90}, Point{5, 15}, 10, color)
+	if ppm.data[15][15] != color || ppm.data[5][15] != color || ppm.data[5][5] != color || ppm.data[10][10] == color {
+		t.Error("Square drawn by the turtle is wrong")
+	}
+}
@@ -0,0 +1,87 @@
+package Netpbm
+
+import "math"
+
+// Matrix représente une transformation affine du plan, avec la même convention que le SVG :
+// x' = A*x + C*y + E et y' = B*x + D*y + F.
+type Matrix struct {
+	A, B, C, D, E, F float64
+}
+
+// IdentityMatrix renvoie la transformation identité.
+func IdentityMatrix() Matrix {
+	return Matrix{A: 1, D: 1}
+}
+
+// TranslateMatrix renvoie une translation de (tx, ty).
+func TranslateMatrix(tx, ty float64) Matrix {
+	return Matrix{A: 1, D: 1, E: tx, F: ty}
+}
+
+// ScaleMatrix renvoie une mise à l'échelle de facteurs (sx, sy).
+func ScaleMatrix(sx, sy float64) Matrix {
+	return Matrix{A: sx, D: sy}
+}
+
+// RotateMatrix renvoie une rotation de angle degrés autour de l'origine.
+// L'axe y étant orienté vers le bas, un angle positif tourne dans le sens des aiguilles d'une montre.
+func RotateMatrix(angle float64) Matrix {
+	rad := angle * math.Pi / 180
+	cos, sin := math.Cos(rad), math.Sin(rad)
+	return Matrix{A: cos, B: sin, C: -sin, D: cos}
+}
+
+// Multiply renvoie la transformation qui applique n puis m.
+func (m Matrix) Multiply(n Matrix) Matrix {
+	return Matrix{
+		A: m.A*n.A + m.C*n.B,
+		B: m.B*n.A + m.D*n.B,
+		C: m.A*n.C + m.C*n.D,
+		D: m.B*n.C + m.D*n.D,
+		E: m.A*n.E + m.C*n.F + m.E,
+		F: m.B*n.E + m.D*n.F + m.F,
+	}
+}
+
+// Translate renvoie m précédée d'une translation, exprimée dans le repère local de m.
+func (m Matrix) Translate(tx, ty float64) Matrix {
+	return m.Multiply(TranslateMatrix(tx, ty))
+}
+
+// Scale renvoie m précédée d'une mise à l'échelle, exprimée dans le repère local de m.
+func (m Matrix) Scale(sx, sy float64) Matrix {
+	return m.Multiply(ScaleMatrix(sx, sy))
+}
+
+// Rotate renvoie m précédée d'une rotation de angle degrés, exprimée dans le repère local de m.
+func (m Matrix) Rotate(angle float64) Matrix {
+	return m.Multiply(RotateMatrix(angle))
+}
+
+// Apply applique la transformation au point p.
+func (m Matrix) Apply(p PointF) PointF {
+	return PointF{X: m.A*p.X + m.C*p.Y + m.E, Y: m.B*p.X + m.D*p.Y + m.F}
+}
+
+// Invert renvoie la transformation inverse, et false si la matrice n'est pas inversible.
+func (m Matrix) Invert() (Matrix, bool) {
+	det := m.A*m.D - m.B*m.C
+	if det == 0 {
+		return Matrix{}, false
+	}
+	return Matrix{
+		A: m.D / det,
+		B: -m.B / det,
+		C: -m.C / det,
+		D: m.A / det,
+		E: (m.C*m.F - m.D*m.E) / det,
+		F: (m.B*m.E - m.A*m.F) / det,
+	}, true
+}
+
+// Transform renvoie une copie du chemin dont tous les points sont transformés par m.
+// Les courbes étant déjà aplaties, un fort agrandissement peut rendre visibles les segments ;
+// il vaut mieux alors réduire la tolérance du chemin avant de le construire.
+func (p *Path) Transform(m Matrix) *Path {
+	return &Path{*p.Path.Map(m.Apply)}
+}
@@ -0,0 +1,53 @@
+package Netpbm
+
+import "github.com/dada416-lebg/Netpbm/internal/noise"
+
+// NoiseType choisit la fonction de bruit de base.
+type NoiseType = noise.Type
+
+const (
+	// NoisePerlin est le bruit de gradient de Perlin.
+	NoisePerlin = noise.Perlin
+	// NoiseValue interpole des valeurs aléatoires placées sur une grille.
+	NoiseValue = noise.Value
+	// NoiseWorley (ou bruit cellulaire) est la distance au point caractéristique le plus proche.
+	NoiseWorley = noise.Worley
+)
+
+// NoiseOptions regroupe les paramètres d'un générateur de bruit fractal.
+type NoiseOptions = noise.Options
+
+// Noise est un générateur de bruit déterministe.
+type Noise = noise.Noise
+
+// DefaultNoiseOptions renvoie des paramètres par défaut : bruit de Perlin d'une octave, graine 42, cellules de 50 pixels.
+// Ce sont les paramètres utilisés par DrawPerlinNoise.
+func DefaultNoiseOptions() NoiseOptions {
+	return noise.DefaultOptions()
+}
+
+// NewNoise crée un générateur de bruit. Les paramètres nuls ou négatifs prennent la valeur par défaut.
+func NewNoise(options NoiseOptions) *Noise {
+	return noise.New(options)
+}
+
+// DrawNoise remplit l'image avec un bruit dont les valeurs vont de color1 (0) à color2 (1).
+func (ppm *PPM) DrawNoise(options NoiseOptions, color1, color2 Pixel) {
+	generator := NewNoise(options)
+	for y := 0; y < ppm.height; y++ {
+		for x := 0; x < ppm.width; x++ {
+			ppm.data[y][x] = interpolateColor(color1, color2, generator.At(float64(x), float64(y)))
+		}
+	}
+}
+
+// DrawNoiseWithStops remplit l'image avec un bruit coloré par les arrêts de couleur donnés.
+func (ppm *PPM) DrawNoiseWithStops(options NoiseOptions, stops ...ColorStop) {
+	generator := NewNoise(options)
+	stops = sortStops(stops)
+	for y := 0; y < ppm.height; y++ {
+		for x := 0; x < ppm.width; x++ {
+			ppm.data[y][x] = colorAtStops(stops, generator.At(float64(x), float64(y)))
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package Netpbm
+
+import "testing"
+
+func TestNoiseDeterministic(t *testing.T) {
+	for _, noiseType := range []NoiseType{NoisePerlin, NoiseValue, NoiseWorley} {
+		options := DefaultNoiseOptions()
+		options.Type = noiseType
+		options.Octaves = 4
+		a, b := NewNoise(options), NewNoise(options)
+		options.Seed = 7
+		c := NewNoise(options)
+
+		different := false
+		for i := 0; i < 100; i++ {
+			x, y := float64(i*13), float64(i*7)
+			if a.At(x, y) != b.At(x, y) {
+				t.Fatalf("Noise type %d is not deterministic", noiseType)
+			}
+			if a.At(x, y) != c.At(x, y) {
+				different = true
+			}
+		}
+		if !different {
+			t.Errorf("Noise type %d ignores the seed", noiseType)
+		}
+	}
+}
+
+func TestNoiseNormalized(t *testing.T) {
+	for _, noiseType := range []NoiseType{NoisePerlin, NoiseValue, NoiseWorley} {
+		options := NoiseOptions{Type: noiseType, Seed: 1, Octaves: 3, Scale: 8}
+		noise := NewNoise(options)
+		low, high := 1.0, 0.0
+		for y := 0; y < 200; y++ {
+			for x := 0; x < 200; x++ {
+				v := noise.At(float64(x), float64(y))
+				if v < 0 || v > 1 {
+					t.Fatalf("Noise type %d out of range: %f", noiseType, v)
+				}
+				low, high = min(low, v), max(high, v)
+			}
+		}
+		// La sortie doit couvrir une bonne partie de l'intervalle au lieu d'être écrêtée
+		if low > 0.35 || high < 0.65 {
+			t.Errorf("Noise type %d poorly spread: [%f, %f]", noiseType, low, high)
+		}
+	}
+}
+
+func TestNoiseOffset(t *testing.T) {
+	options := DefaultNoiseOptions()
+	shifted := options
+	shifted.OffsetX, shifted.OffsetY = 30, -12
+	a, b := NewNoise(options), NewNoise(shifted)
+	if a.At(40, 8) != b.At(10, 20) {
+		t.Error("Offset should shift the sampling position")
+	}
+}
+
+func TestPPMDrawPerlinNoise(t *testing.T) {
+	ppm := newBlankPPM(100, 100)
+	ppm.DrawPerlinNoise(Pixel{0, 0, 0}, Pixel{255, 255, 255})
+	// Avant la normalisation, la moitié de l'image était de la couleur color1
+	if n := countPixels(ppm, Pixel{0, 0, 0}); n > 100 {
+		t.Errorf("Too many pixels clamped to color1: %d", n)
+	}
+}
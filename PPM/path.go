@@ -0,0 +1,70 @@
+package Netpbm
+
+import "github.com/dada416-lebg/Netpbm/internal/raster"
+
+// PointF représente un point en coordonnées flottantes.
+type PointF = raster.PointF
+
+// FillRule définit la règle utilisée pour décider si un point est à l'intérieur d'un chemin.
+type FillRule = raster.FillRule
+
+const (
+	// FillNonZero remplit les zones dont l'indice d'enroulement est non nul.
+	FillNonZero = raster.FillNonZero
+	// FillEvenOdd remplit les zones traversées un nombre impair de fois.
+	FillEvenOdd = raster.FillEvenOdd
+)
+
+// Path représente un chemin vectoriel composé d'un ou plusieurs sous-chemins. La construction
+// et l'aplatissement des courbes sont communs aux trois formats.
+type Path struct {
+	raster.Path
+}
+
+// NewPath crée un chemin vide.
+func NewPath() *Path {
+	return &Path{*raster.NewPath()}
+}
+
+// StrokePath trace le contour du chemin avec des lignes d'un pixel.
+func (ppm *PPM) StrokePath(path *Path, color Pixel) {
+	for _, polyline := range path.Polylines() {
+		if len(polyline) == 1 {
+			ppm.plot(raster.Round(polyline[0].X), raster.Round(polyline[0].Y), color)
+			continue
+		}
+		for i := 0; i+1 < len(polyline); i++ {
+			ppm.DrawLine(roundPoint(polyline[i]), roundPoint(polyline[i+1]), color)
+		}
+	}
+}
+
+// StrokePathWidth trace le contour du chemin avec un trait d'épaisseur width et des jointures arrondies.
+func (ppm *PPM) StrokePathWidth(path *Path, width float64, color Pixel) {
+	if width <= 1 {
+		ppm.StrokePath(path, color)
+		return
+	}
+	polygons := raster.StrokePolygons(&path.Path, width, path.EffectiveTolerance())
+	raster.FillPolygons(polygons, FillNonZero, ppm.width, ppm.height, ppm.fillSpan(color))
+}
+
+// FillPath remplit l'intérieur du chemin selon sa règle de remplissage.
+// Les sous-chemins ouverts sont implicitement fermés.
+func (ppm *PPM) FillPath(path *Path, color Pixel) {
+	raster.FillPolygons(path.Polylines(), path.FillRule, ppm.width, ppm.height, ppm.fillSpan(color))
+}
+
+// fillSpan renvoie une fonction qui remplit les pixels [x0, x1) de la ligne y.
+func (ppm *PPM) fillSpan(color Pixel) func(y, x0, x1 int) {
+	return func(y, x0, x1 int) {
+		for x := x0; x < x1; x++ {
+			ppm.data[y][x] = color
+		}
+	}
+}
+
+// roundPoint arrondit un point flottant au pixel le plus proche.
+func roundPoint(p PointF) Point {
+	return Point{raster.Round(p.X), raster.Round(p.Y)}
+}
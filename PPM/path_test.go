@@ -0,0 +1,156 @@
+package Netpbm
+
+import (
+	"math"
+	"testing"
+)
+
+// newBlankPPM crée une image blanche en mémoire pour les tests.
+func newBlankPPM(width, height int) *PPM {
+	data := make([][]Pixel, height)
+	for y := range data {
+		data[y] = make([]Pixel, width)
+		for x := range data[y] {
+			data[y][x] = Pixel{255, 255, 255}
+		}
+	}
+	return &PPM{data, width, height, "P3", 255}
+}
+
+func countPixels(ppm *PPM, color Pixel) int {
+	count := 0
+	for y := 0; y < ppm.height; y++ {
+		for x := 0; x < ppm.width; x++ {
+			if ppm.data[y][x] == color {
+				count++
+			}
+		}
+	}
+	return count
+}
+
+func TestPPMFillPathSquare(t *testing.T) {
+	ppm := newBlankPPM(10, 10)
+	red := Pixel{255, 0, 0}
+	path := NewPath()
+	path.MoveTo(2, 2)
+	path.LineTo(6, 2)
+	path.LineTo(6, 6)
+	path.LineTo(2, 6)
+	path.Close()
+	ppm.FillPath(path, red)
+
+	for y := 0; y < 10; y++ {
+		for x := 0; x < 10; x++ {
+			inside := x >= 2 && x < 6 && y >= 2 && y < 6
+			if (ppm.data[y][x] == red) != inside {
+				t.Errorf("Pixel at (%d, %d) not filled correctly", x, y)
+			}
+		}
+	}
+}
+
+func TestPPMFillPathEvenOdd(t *testing.T) {
+	red := Pixel{255, 0, 0}
+	square := func(path *Path, x0, y0, x1, y1 float64) {
+		path.MoveTo(x0, y0)
+		path.LineTo(x1, y0)
+		path.LineTo(x1, y1)
+		path.LineTo(x0, y1)
+		path.Close()
+	}
+
+	path := NewPath()
+	square(path, 0, 0, 10, 10)
+	square(path, 3, 3, 7, 7)
+
+	ppm := newBlankPPM(10, 10)
+	ppm.FillPath(path, red)
+	if countPixels(ppm, red) != 100 {
+		t.Error("Non-zero rule should fill the inner square")
+	}
+
+	path.FillRule = FillEvenOdd
+	ppm = newBlankPPM(10, 10)
+	ppm.FillPath(path, red)
+	if countPixels(ppm, red) != 100-16 {
+		t.Error("Even-odd rule should leave a hole")
+	}
+}
+
+func TestPathCubicFlattening(t *testing.T) {
+	path := NewPath()
+	path.Tolerance = 0.1
+	path.MoveTo(0, 0)
+	path.CubicTo(0, 100, 100, 100, 100, 0)
+
+	points := path.Polylines()[0]
+	if len(points) < 8 {
+		t.Errorf("Curve flattened into too few segments: %d", len(points))
+	}
+	if points[len(points)-1] != (PointF{X: 100, Y: 0}) {
+		t.Error("Curve does not end on its end point")
+	}
+	// Le sommet de la courbe est atteint en t = 0.5, à y = 75
+	maxY := 0.0
+	for _, p := range points {
+		maxY = math.Max(maxY, p.Y)
+	}
+	if math.Abs(maxY-75) > 0.5 {
+		t.Errorf("Curve apex wrong: got %f", maxY)
+	}
+}
+
+func TestPathArcTo(t *testing.T) {
+	path := NewPath()
+	path.MoveTo(0, 10)
+	path.ArcTo(10, 10, 0, false, true, 20, 10)
+
+	points := path.Polylines()[0]
+	if points[len(points)-1] != (PointF{X: 20, Y: 10}) {
+		t.Error("Arc does not end on its end point")
+	}
+	for _, p := range points {
+		if d := math.Hypot(p.X-10, p.Y-10); math.Abs(d-10) > 0.3 {
+			t.Errorf("Point %v is not on the circle", p)
+		}
+	}
+}
+
+func TestPPMStrokePath(t *testing.T) {
+	ppm := newBlankPPM(15, 15)
+	green := Pixel{0, 255, 0}
+	path := NewPath()
+	path.MoveTo(1, 1)
+	path.LineTo(12, 1)
+	path.LineTo(12, 12)
+	path.Close()
+	ppm.StrokePath(path, green)
+
+	for _, p := range []Point{{1, 1}, {6, 1}, {12, 1}, {12, 6}, {12, 12}, {6, 6}} {
+		if ppm.data[p.Y][p.X] != green {
+			t.Errorf("Pixel at (%d, %d) not stroked", p.X, p.Y)
+		}
+	}
+	if ppm.data[10][3] == green {
+		t.Error("Stroke should not fill the path")
+	}
+}
+
+func TestPPMStrokePathWidth(t *testing.T) {
+	ppm := newBlankPPM(20, 20)
+	blue := Pixel{0, 0, 255}
+	path := NewPath()
+	path.MoveTo(2, 10)
+	path.LineTo(18, 10)
+	ppm.StrokePathWidth(path, 4, blue)
+
+	for y := 8; y < 12; y++ {
+		if ppm.data[y][10] != blue {
+			t.Errorf("Pixel at (10, %d) not stroked", y)
+		}
+	}
+	if ppm.data[5][10] == blue || ppm.data[14][10] == blue {
+		t.Error("Stroke is too wide")
+	}
+}
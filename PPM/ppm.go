@@ -9,7 +9,7 @@ import (
 	"strconv"
 	"strings"
 
-	"github.com/aquilax/go-perlin"
+	"github.com/dada416-lebg/Netpbm/internal/raster"
 )
 
 type PPM struct {
@@ -24,6 +24,21 @@ type Pixel struct {
 	R, G, B uint8
 }
 
+// NewPPM crée une image PPM noire de width × height pixels, au format P3 avec une valeur maximale de 255.
+func NewPPM(width, height int) *PPM {
+	ppm := &PPM{
+		data:        make([][]Pixel, height),
+		width:       width,
+		height:      height,
+		magicNumber: "P3",
+		max:         255,
+	}
+	for y := range ppm.data {
+		ppm.data[y] = make([]Pixel, width)
+	}
+	return ppm
+}
+
 // ReadPPM lit une image PPM à partir d'un fichier et renvoie une structure représentant l'image.
 func ReadPPM(filename string) (*PPM, error) {
 	file, err := os.Open(filename)
@@ -317,7 +332,22 @@ type Point struct {
 
 // DrawLine draws a line between two points.
 func (ppm *PPM) DrawLine(p1, p2 Point, color Pixel) {
-	// ...
+	// Les pixels hors de l'image sont ignorés
+	bresenham(p1, p2, func(x, y int) {
+		ppm.plot(x, y, color)
+	})
+}
+
+// bresenham appelle plot pour chaque pixel du segment allant de p1 à p2.
+func bresenham(p1, p2 Point, plot func(x, y int)) {
+	raster.Line(p1.X, p1.Y, p2.X, p2.Y, plot)
+}
+
+// plot définit la couleur du pixel (x, y) s'il se trouve dans l'image.
+func (ppm *PPM) plot(x, y int, color Pixel) {
+	if x >= 0 && x < ppm.width && y >= 0 && y < ppm.height {
+		ppm.data[y][x] = color
+	}
 }
 
 // DrawRectangle dessine un rectangle dans l'image PPM.
@@ -636,88 +666,112 @@ func (ppm *PPM) DrawFilledPolygon(points []Point, color Pixel) {
 	}
 }
 
-// DrawKochSnowflake dessine un flocon de neige de Koch récursif.
+// DrawKochSnowflake dessine le contour d'un flocon de Koch d'ordre n.
+// Le flocon est construit sur un triangle équilatéral de côté length dont la base part de start
+// vers la droite et dont le sommet est au-dessus de la base.
 func (ppm *PPM) DrawKochSnowflake(n int, start Point, length int, color Pixel) {
-	// Définir les angles pour les segments du flocon de neige
-	angles := []float64{0, 120, -120, 0}
-
-	// Dessiner la première section du flocon de neige
-	ppm.drawKochSegment(n, start, length, angles, color)
-}
-
-// drawKochSegment dessine un segment du flocon de neige de Koch récursif.
-func (ppm *PPM) drawKochSegment(n int, start Point, length int, angles []float64, color Pixel) {
-	if n == 0 {
-		// Cas de base : dessiner un segment
-		end := Point{start.X + length, start.Y}
-		ppm.DrawLine(start, end, color)
-	} else {
-		// Calculer les nouveaux points pour diviser le segment en trois parties égales
-		third := length / 3
-		p1 := start
-		p2 := Point{start.X + third, start.Y}
-		p3 := Point{start.X + third, start.Y + int(float64(third)*math.Sqrt(3))}
-		p4 := Point{start.X + 2*third, start.Y + int(float64(third)*math.Sqrt(3))}
-		p5 := Point{start.X + 2*third, start.Y}
-		p6 := Point{start.X + 3*third, start.Y}
-
-		// Dessiner les segments récursivement
-		ppm.drawKochSegment(n-1, p1, third, angles, color)
-		ppm.drawKochSegment(n-1, p2, third, angles, color)
-		ppm.drawKochSegment(n-1, p3, third, angles, color)
-		ppm.drawKochSegment(n-1, p4, third, angles, color)
-		ppm.drawKochSegment(n-1, p5, third, angles, color)
-		ppm.drawKochSegment(n-1, p6, third, angles, color)
-	}
-}
-
-// DrawSierpinskiTriangle dessine un triangle de Sierpinski récursif.
-func (ppm *PPM) DrawSierpinskiTriangle(n int, start Point, width int, color Pixel) {
-	if n == 0 {
-		// Cas de base : dessiner un triangle
-		p1 := start
-		p2 := Point{start.X + width, start.Y}
-		p3 := Point{start.X + width/2, start.Y - int(float64(width)*math.Sqrt(3)/2)}
+	points := kochSnowflake(n, start, length)
+	for i := 0; i < len(points); i++ {
+		ppm.DrawLine(roundPoint(points[i]), roundPoint(points[(i+1)%len(points)]), color)
+	}
+}
+
+// DrawFilledKochSnowflake dessine un flocon de Koch d'ordre n rempli.
+func (ppm *PPM) DrawFilledKochSnowflake(n int, start Point, length int, color Pixel) {
+	points := kochSnowflake(n, start, length)
+	polygon := append(points, points[0])
+	raster.FillPolygons([][]PointF{polygon}, FillNonZero, ppm.width, ppm.height, func(y, x0, x1 int) {
+		for x := x0; x < x1; x++ {
+			ppm.data[y][x] = color
+		}
+	})
+}
 
-		ppm.DrawLine(p1, p2, color)
-		ppm.DrawLine(p2, p3, color)
-		ppm.DrawLine(p3, p1, color)
-	} else {
-		// Calculer les points pour diviser le triangle en trois parties égales
-		thirdWidth := width / 2
-		p1 := start
-		p2 := Point{start.X + width, start.Y}
-		p3 := Point{start.X + thirdWidth, start.Y - int(float64(width)*math.Sqrt(3)/2)}
+// kochSnowflake renvoie les sommets du flocon, en parcourant le triangle de départ dans le sens
+// des aiguilles d'une montre à l'écran.
+func kochSnowflake(n int, start Point, length int) []PointF {
+	a := PointF{X: float64(start.X), Y: float64(start.Y)}
+	b := PointF{X: a.X + float64(length), Y: a.Y}
+	c := PointF{X: a.X + float64(length)/2, Y: a.Y - float64(length)*math.Sqrt(3)/2}
 
-		// Calculer le point au sommet du triangle intérieur
-		topPoint := Point{start.X + thirdWidth/2, start.Y - int(float64(thirdWidth)*math.Sqrt(3)/2)}
+	var points []PointF
+	points = kochSegment(points, n, a, c)
+	points = kochSegment(points, n, c, b)
+	points = kochSegment(points, n, b, a)
+	return points
+}
 
-		// Dessiner les triangles récursivement
-		ppm.DrawSierpinskiTriangle(n-1, p1, thirdWidth, color)
-		ppm.DrawSierpinskiTriangle(n-1, topPoint, thirdWidth, color)
-		ppm.DrawSierpinskiTriangle(n-1, p2, thirdWidth, color)
-		ppm.DrawSierpinskiTriangle(n-1, p3, thirdWidth, color)
+// kochSegment ajoute à points les sommets de la courbe de Koch d'ordre n allant de a à b, sans b.
+// La bosse est obtenue en tournant le tiers central de 60° vers l'extérieur du flocon,
+// quelle que soit l'orientation du segment.
+func kochSegment(points []PointF, n int, a, b PointF) []PointF {
+	if n <= 0 {
+		return append(points, a)
 	}
+	dx, dy := (b.X-a.X)/3, (b.Y-a.Y)/3
+	p1 := PointF{X: a.X + dx, Y: a.Y + dy}
+	p3 := PointF{X: a.X + 2*dx, Y: a.Y + 2*dy}
+	// Rotation de -60° du vecteur (dx, dy)
+	cos, sin := 0.5, -math.Sqrt(3)/2
+	p2 := PointF{X: p1.X + dx*cos - dy*sin, Y: p1.Y + dx*sin + dy*cos}
+
+	points = kochSegment(points, n-1, a, p1)
+	points = kochSegment(points, n-1, p1, p2)
+	points = kochSegment(points, n-1, p2, p3)
+	return kochSegment(points, n-1, p3, b)
 }
 
-// DrawPerlinNoise draws Perlin noise on the image.
-func (ppm *PPM) DrawPerlinNoise(color1, color2 Pixel) {
-	// Set up Perlin noise generator
-	p := perlin.NewPerlin(3, 3, 1, 42)
+// DrawSierpinskiTriangle dessine le contour des triangles d'un triangle de Sierpinski d'ordre n.
+// La base du triangle, de largeur width, part de start vers la droite et le sommet est au-dessus.
+func (ppm *PPM) DrawSierpinskiTriangle(n int, start Point, width int, color Pixel) {
+	for _, triangle := range sierpinskiTriangles(n, start, width) {
+		ppm.DrawLine(roundPoint(triangle[0]), roundPoint(triangle[1]), color)
+		ppm.DrawLine(roundPoint(triangle[1]), roundPoint(triangle[2]), color)
+		ppm.DrawLine(roundPoint(triangle[2]), roundPoint(triangle[0]), color)
+	}
+}
 
-	// Loop through each pixel in the image
-	for y := 0; y < ppm.height; y++ {
-		for x := 0; x < ppm.width; x++ {
-			// Generate Perlin noise value for the current pixel
-			noiseValue := p.Noise2D(float64(x)/50, float64(y)/50)
+// DrawFilledSierpinskiTriangle dessine un triangle de Sierpinski d'ordre n dont les triangles sont remplis.
+func (ppm *PPM) DrawFilledSierpinskiTriangle(n int, start Point, width int, color Pixel) {
+	triangles := sierpinskiTriangles(n, start, width)
+	polygons := make([][]PointF, len(triangles))
+	for i, triangle := range triangles {
+		polygons[i] = []PointF{triangle[0], triangle[1], triangle[2], triangle[0]}
+	}
+	raster.FillPolygons(polygons, FillNonZero, ppm.width, ppm.height, func(y, x0, x1 int) {
+		for x := x0; x < x1; x++ {
+			ppm.data[y][x] = color
+		}
+	})
+}
 
-			// Map the noise value to a color between color1 and color2
-			color := interpolateColor(color1, color2, noiseValue)
+// sierpinskiTriangles renvoie les 3^n triangles pleins du triangle de Sierpinski d'ordre n.
+func sierpinskiTriangles(n int, start Point, width int) [][3]PointF {
+	a := PointF{X: float64(start.X), Y: float64(start.Y)}
+	b := PointF{X: a.X + float64(width), Y: a.Y}
+	c := PointF{X: a.X + float64(width)/2, Y: a.Y - float64(width)*math.Sqrt(3)/2}
 
-			// Set the color for the current pixel
-			ppm.Set(x, y, color)
+	var triangles [][3]PointF
+	var subdivide func(n int, a, b, c PointF)
+	subdivide = func(n int, a, b, c PointF) {
+		if n <= 0 {
+			triangles = append(triangles, [3]PointF{a, b, c})
+			return
 		}
+		// Le triangle central, formé par les milieux des côtés, est retiré
+		ab, bc, ca := raster.MidPoint(a, b), raster.MidPoint(b, c), raster.MidPoint(c, a)
+		subdivide(n-1, a, ab, ca)
+		subdivide(n-1, ab, b, bc)
+		subdivide(n-1, ca, bc, c)
 	}
+	subdivide(n, a, b, c)
+	return triangles
+}
+
+// DrawPerlinNoise draws Perlin noise on the image.
+// It uses DefaultNoiseOptions; see DrawNoise for a configurable generator.
+func (ppm *PPM) DrawPerlinNoise(color1, color2 Pixel) {
+	ppm.DrawNoise(DefaultNoiseOptions(), color1, color2)
 }
 
 // interpolateColor linearly interpolates between two colors based on a t value.
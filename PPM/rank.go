@@ -0,0 +1,57 @@
+package Netpbm
+
+import (
+	"fmt"
+
+	"github.com/dada416-lebg/Netpbm/internal/raster"
+)
+
+// MedianFilter remplace chaque composante de chaque pixel par la médiane du carré de côté
+// 2 × radius + 1 qui l'entoure. C'est le filtre de référence contre le bruit « poivre et sel ».
+func (ppm *PPM) MedianFilter(radius int) {
+	ppm.RankFilter(radius, 50)
+}
+
+// MinFilter remplace chaque composante par la plus petite valeur du carré de côté 2 × radius + 1 qui l'entoure.
+func (ppm *PPM) MinFilter(radius int) {
+	ppm.RankFilter(radius, 0)
+}
+
+// MaxFilter remplace chaque composante par la plus grande valeur du carré de côté 2 × radius + 1 qui l'entoure.
+func (ppm *PPM) MaxFilter(radius int) {
+	ppm.RankFilter(radius, 100)
+}
+
+// RankFilter remplace chaque composante de chaque pixel par le centile percentile, entre 0 et 100,
+// des valeurs du carré de côté 2 × radius + 1 qui l'entoure. Les composantes sont filtrées séparément
+// et les bords sont prolongés. Le calcul utilise des histogrammes glissants : son coût ne dépend pas du rayon.
+func (ppm *PPM) RankFilter(radius int, percentile float64) {
+	if radius <= 0 {
+		fmt.Println("Le rayon du filtre doit être positif.")
+		return
+	}
+	if percentile < 0 || percentile > 100 {
+		fmt.Println("Le centile doit être compris entre 0 et 100.")
+		return
+	}
+	var planes [3][]uint8
+	for c := range planes {
+		planes[c] = make([]uint8, ppm.width*ppm.height)
+	}
+	for y := 0; y < ppm.height; y++ {
+		for x := 0; x < ppm.width; x++ {
+			p := ppm.data[y][x]
+			i := y*ppm.width + x
+			planes[0][i], planes[1][i], planes[2][i] = p.R, p.G, p.B
+		}
+	}
+	for c := range planes {
+		planes[c] = raster.RankPlane(planes[c], ppm.width, ppm.height, radius, percentile)
+	}
+	for y := 0; y < ppm.height; y++ {
+		for x := 0; x < ppm.width; x++ {
+			i := y*ppm.width + x
+			ppm.data[y][x] = Pixel{planes[0][i], planes[1][i], planes[2][i]}
+		}
+	}
+}
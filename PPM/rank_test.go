@@ -0,0 +1,23 @@
+package Netpbm
+
+import "testing"
+
+func TestPPMRankFilters(t *testing.T) {
+	ppm := newBlankPPM(8, 8)
+	ppm.data[3][3] = Pixel{0, 0, 0}
+	ppm.data[5][2] = Pixel{255, 0, 255}
+	ppm.MedianFilter(1)
+	if countPixels(ppm, Pixel{255, 255, 255}) != 64 {
+		t.Fatalf("MedianFilter should remove isolated noise: %v", ppm.data)
+	}
+
+	ppm.data[4][4] = Pixel{255, 10, 255}
+	ppm.MinFilter(1)
+	if countPixels(ppm, Pixel{255, 10, 255}) != 9 {
+		t.Fatalf("MinFilter should work per channel: %v", ppm.data)
+	}
+	ppm.MaxFilter(1)
+	if countPixels(ppm, Pixel{255, 255, 255}) != 63 || ppm.data[4][4] != (Pixel{255, 10, 255}) {
+		t.Fatalf("MaxFilter should shrink the dark area back: %v", ppm.data)
+	}
+}
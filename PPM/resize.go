@@ -0,0 +1,70 @@
+package Netpbm
+
+import (
+	"fmt"
+
+	"github.com/dada416-lebg/Netpbm/internal/raster"
+)
+
+// ResizeFilter est le filtre de reconstruction utilisé pour rééchantillonner une image.
+type ResizeFilter = raster.ResizeFilter
+
+const (
+	// FilterBox fait la moyenne des pixels couverts ; en réduction, c'est une moyenne par surface.
+	FilterBox = raster.FilterBox
+	// FilterBilinear est le filtre triangle, qui interpole linéairement entre deux pixels voisins.
+	FilterBilinear = raster.FilterBilinear
+	// FilterBicubic est la spline cubique de Catmull-Rom (B = 0, C = 0.5).
+	FilterBicubic = raster.FilterBicubic
+	// FilterMitchell est le filtre cubique de Mitchell-Netravali (B = C = 1/3).
+	FilterMitchell = raster.FilterMitchell
+	// FilterLanczos est le filtre de Lanczos à trois lobes.
+	FilterLanczos = raster.FilterLanczos
+)
+
+// Resize redimensionne l'image à width × height pixels avec le filtre donné.
+// Le calcul est fait en lumière linéaire, ce qui évite d'assombrir les détails fins lors d'une réduction.
+func (ppm *PPM) Resize(width, height int, filter ResizeFilter) {
+	if width <= 0 || height <= 0 {
+		fmt.Println("Les dimensions de l'image redimensionnée ne sont pas valides.")
+		return
+	}
+	maxValue := ppm.max
+	if maxValue <= 0 {
+		maxValue = 255
+	}
+	toLinear := raster.LinearTable(maxValue)
+
+	// Passe horizontale : height d'origine × nouvelle largeur, trois composantes par pixel
+	horizontal := raster.Contributions(ppm.width, width, filter)
+	rows := make([][]float64, ppm.height)
+	for y := range rows {
+		rows[y] = make([]float64, width*3)
+		src := ppm.data[y]
+		for x, c := range horizontal {
+			rows[y][3*x] = c.Resample(func(i int) float64 { return toLinear[src[i].R] }, ppm.width)
+			rows[y][3*x+1] = c.Resample(func(i int) float64 { return toLinear[src[i].G] }, ppm.width)
+			rows[y][3*x+2] = c.Resample(func(i int) float64 { return toLinear[src[i].B] }, ppm.width)
+		}
+	}
+
+	// Passe verticale et retour au codage sRGB
+	encode := func(v float64) uint8 {
+		return toUint8(raster.LinearToSRGB(v) * float64(maxValue))
+	}
+	vertical := raster.Contributions(ppm.height, height, filter)
+	data := make([][]Pixel, height)
+	for y, c := range vertical {
+		data[y] = make([]Pixel, width)
+		for x := 0; x < width; x++ {
+			r := c.Resample(func(i int) float64 { return rows[i][3*x] }, ppm.height)
+			g := c.Resample(func(i int) float64 { return rows[i][3*x+1] }, ppm.height)
+			b := c.Resample(func(i int) float64 { return rows[i][3*x+2] }, ppm.height)
+			data[y][x] = Pixel{encode(r), encode(g), encode(b)}
+		}
+	}
+
+	ppm.data = data
+	ppm.width = width
+	ppm.height = height
+}
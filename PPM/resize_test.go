@@ -0,0 +1,56 @@
+package Netpbm
+
+import "testing"
+
+var resizeFilters = []ResizeFilter{FilterBox, FilterBilinear, FilterBicubic, FilterMitchell, FilterLanczos}
+
+func TestPPMResizeUniform(t *testing.T) {
+	color := Pixel{12, 130, 250}
+	for _, filter := range resizeFilters {
+		for _, size := range [][2]int{{5, 3}, {23, 31}} {
+			ppm := newBlankPPM(11, 9)
+			ppm.DrawFilledRectangle(Point{0, 0}, 11, 9, color)
+			ppm.Resize(size[0], size[1], filter)
+			if ppm.width != size[0] || ppm.height != size[1] || len(ppm.data) != size[1] || len(ppm.data[0]) != size[0] {
+				t.Fatalf("Filter %d: wrong size", filter)
+			}
+			if got := countPixels(ppm, color); got != size[0]*size[1] {
+				t.Errorf("Filter %d, %v: uniform image changed (%d pixels kept)", filter, size, got)
+			}
+		}
+	}
+}
+
+func TestPPMResizeLinearLight(t *testing.T) {
+	// Un damier noir et blanc réduit de moitié donne 50 % de lumière, soit 188 en sRGB et non 128
+	ppm := newBlankPPM(8, 8)
+	for y := 0; y < 8; y++ {
+		for x := 0; x < 8; x++ {
+			if (x+y)%2 == 0 {
+				ppm.data[y][x] = Pixel{0, 0, 0}
+			}
+		}
+	}
+	ppm.Resize(4, 4, FilterBox)
+	if got := countPixels(ppm, Pixel{188, 188, 188}); got != 16 {
+		t.Errorf("Checkerboard average wrong: %v", ppm.data[0][0])
+	}
+}
+
+func TestPPMResizeUpscale(t *testing.T) {
+	ppm := newBlankPPM(2, 1)
+	ppm.data[0][0] = Pixel{0, 0, 0}
+
+	box := newBlankPPM(2, 1)
+	box.data[0][0] = Pixel{0, 0, 0}
+	box.Resize(4, 2, FilterBox)
+	if box.data[1][1] != (Pixel{0, 0, 0}) || box.data[0][2] != (Pixel{255, 255, 255}) {
+		t.Error("Box upscale should replicate pixels")
+	}
+
+	ppm.Resize(4, 1, FilterBilinear)
+	row := ppm.data[0]
+	if row[0] != (Pixel{0, 0, 0}) || row[3] != (Pixel{255, 255, 255}) || !(row[1].R < row[2].R) {
+		t.Errorf("Bilinear upscale wrong: %v", row)
+	}
+}
@@ -0,0 +1,143 @@
+package Netpbm
+
+import (
+	"math"
+
+	"github.com/dada416-lebg/Netpbm/internal/raster"
+)
+
+// remap remplace l'image par une image width × height dont le pixel (x, y) est le pixel source(x, y) de l'image d'origine.
+func (ppm *PPM) remap(width, height int, source func(x, y int) (int, int)) {
+	data := make([][]Pixel, height)
+	for y := range data {
+		data[y] = make([]Pixel, width)
+		for x := range data[y] {
+			sx, sy := source(x, y)
+			data[y][x] = ppm.data[sy][sx]
+		}
+	}
+	ppm.data = data
+	ppm.width, ppm.height = width, height
+}
+
+// Rotate90CCW fait pivoter l'image de 90° dans le sens inverse des aiguilles d'une montre.
+func (ppm *PPM) Rotate90CCW() {
+	w := ppm.width
+	ppm.remap(ppm.height, ppm.width, func(x, y int) (int, int) {
+		return w - 1 - y, x
+	})
+}
+
+// Rotate180 fait pivoter l'image de 180°.
+func (ppm *PPM) Rotate180() {
+	w, h := ppm.width, ppm.height
+	ppm.remap(w, h, func(x, y int) (int, int) {
+		return w - 1 - x, h - 1 - y
+	})
+}
+
+// Rotate270CW fait pivoter l'image de 270° dans le sens des aiguilles d'une montre, comme Rotate90CCW.
+func (ppm *PPM) Rotate270CW() {
+	ppm.Rotate90CCW()
+}
+
+// Transpose échange les lignes et les colonnes : l'image est retournée selon sa diagonale principale.
+func (ppm *PPM) Transpose() {
+	ppm.remap(ppm.height, ppm.width, func(x, y int) (int, int) {
+		return y, x
+	})
+}
+
+// Transverse retourne l'image selon son antidiagonale, qui va du coin supérieur droit au coin inférieur gauche.
+func (ppm *PPM) Transverse() {
+	w, h := ppm.width, ppm.height
+	ppm.remap(h, w, func(x, y int) (int, int) {
+		return w - 1 - y, h - 1 - x
+	})
+}
+
+// RotateOptions regroupe les paramètres d'une rotation d'angle quelconque.
+type RotateOptions struct {
+	// Filter est le filtre d'interpolation ; FilterBox revient au plus proche voisin.
+	Filter ResizeFilter
+	// Expand agrandit l'image pour contenir toute l'image tournée ; sinon la taille est conservée
+	// et les coins qui dépassent sont coupés.
+	Expand bool
+	// Background est la couleur des zones qui ne proviennent pas de l'image d'origine.
+	Background Pixel
+}
+
+// Rotate fait pivoter l'image de angle degrés dans le sens des aiguilles d'une montre autour de son centre.
+// Les multiples de 90° sont traités sans perte quand la taille de l'image le permet.
+func (ppm *PPM) Rotate(angle float64, options RotateOptions) {
+	angle = math.Mod(angle, 360)
+	if angle < 0 {
+		angle += 360
+	}
+	switch {
+	case angle == 0:
+		return
+	case angle == 180:
+		ppm.Rotate180()
+		return
+	case (angle == 90 || angle == 270) && (options.Expand || ppm.width == ppm.height):
+		if angle == 90 {
+			ppm.Rotate90CW()
+		} else {
+			ppm.Rotate90CCW()
+		}
+		return
+	}
+
+	rad := angle * math.Pi / 180
+	cos, sin := math.Cos(rad), math.Sin(rad)
+	width, height := ppm.width, ppm.height
+	if options.Expand {
+		width, height = raster.RotatedSize(ppm.width, ppm.height, cos, sin)
+	}
+
+	maxValue := ppm.max
+	if maxValue <= 0 {
+		maxValue = 255
+	}
+	toLinear := raster.LinearTable(maxValue)
+	background := [3]float64{toLinear[options.Background.R], toLinear[options.Background.G], toLinear[options.Background.B]}
+
+	srcCX, srcCY := float64(ppm.width)/2, float64(ppm.height)/2
+	dstCX, dstCY := float64(width)/2, float64(height)/2
+	data := make([][]Pixel, height)
+	for y := range data {
+		data[y] = make([]Pixel, width)
+		for x := range data[y] {
+			// Rotation inverse du centre du pixel, ramenée aux indices de la source
+			dx, dy := float64(x)+0.5-dstCX, float64(y)+0.5-dstCY
+			u := cos*dx + sin*dy + srcCX - 0.5
+			v := -sin*dx + cos*dy + srcCY - 0.5
+
+			var sum [3]float64
+			total := 0.0
+			options.Filter.Sample(u, v, func(i, j int, w float64) {
+				sample := background
+				if i >= 0 && i < ppm.width && j >= 0 && j < ppm.height {
+					p := ppm.data[j][i]
+					sample = [3]float64{toLinear[p.R], toLinear[p.G], toLinear[p.B]}
+				}
+				for c := range sum {
+					sum[c] += w * sample[c]
+				}
+				total += w
+			})
+			if total == 0 {
+				data[y][x] = options.Background
+				continue
+			}
+			encode := func(c int) uint8 {
+				return toUint8(raster.LinearToSRGB(sum[c]/total) * float64(maxValue))
+			}
+			data[y][x] = Pixel{encode(0), encode(1), encode(2)}
+		}
+	}
+
+	ppm.data = data
+	ppm.width, ppm.height = width, height
+}
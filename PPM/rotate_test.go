@@ -0,0 +1,98 @@
+package Netpbm
+
+import "testing"
+
+// numberedPPM renvoie une image 3 × 2 dont chaque pixel est unique.
+func numberedPPM() *PPM {
+	ppm := newBlankPPM(3, 2)
+	for y := 0; y < 2; y++ {
+		for x := 0; x < 3; x++ {
+			ppm.data[y][x] = Pixel{uint8(10*y + x), 0, 0}
+		}
+	}
+	return ppm
+}
+
+func redRows(ppm *PPM) [][]uint8 {
+	rows := make([][]uint8, ppm.height)
+	for y := range rows {
+		for x := 0; x < ppm.width; x++ {
+			rows[y] = append(rows[y], ppm.data[y][x].R)
+		}
+	}
+	return rows
+}
+
+func TestPPMOrthogonalTransforms(t *testing.T) {
+	// Image d'origine :
+	//  0  1  2
+	// 10 11 12
+	tests := []struct {
+		name      string
+		transform func(*PPM)
+		want      [][]uint8
+	}{
+		{"Rotate90CW", (*PPM).Rotate90CW, [][]uint8{{10, 0}, {11, 1}, {12, 2}}},
+		{"Rotate90CCW", (*PPM).Rotate90CCW, [][]uint8{{2, 12}, {1, 11}, {0, 10}}},
+		{"Rotate180", (*PPM).Rotate180, [][]uint8{{12, 11, 10}, {2, 1, 0}}},
+		{"Rotate270CW", (*PPM).Rotate270CW, [][]uint8{{2, 12}, {1, 11}, {0, 10}}},
+		{"Transpose", (*PPM).Transpose, [][]uint8{{0, 10}, {1, 11}, {2, 12}}},
+		{"Transverse", (*PPM).Transverse, [][]uint8{{12, 2}, {11, 1}, {10, 0}}},
+	}
+	for _, test := range tests {
+		ppm := numberedPPM()
+		test.transform(ppm)
+		got := redRows(ppm)
+		if len(got) != len(test.want) {
+			t.Errorf("%s: wrong size %dx%d", test.name, ppm.width, ppm.height)
+			continue
+		}
+		for y := range got {
+			for x := range got[y] {
+				if got[y][x] != test.want[y][x] {
+					t.Errorf("%s: wanted %v got %v", test.name, test.want, got)
+					break
+				}
+			}
+		}
+	}
+}
+
+func TestPPMRotateRightAngleIsLossless(t *testing.T) {
+	a, b := numberedPPM(), numberedPPM()
+	a.Rotate(-270, RotateOptions{Filter: FilterLanczos, Expand: true})
+	b.Rotate90CW()
+	if a.width != b.width || a.height != b.height {
+		t.Fatal("Rotate(90) changed the size")
+	}
+	for y := range a.data {
+		for x := range a.data[y] {
+			if a.data[y][x] != b.data[y][x] {
+				t.Fatal("Rotate(90) should be lossless")
+			}
+		}
+	}
+}
+
+func TestPPMRotateArbitrary(t *testing.T) {
+	color := Pixel{90, 160, 30}
+	background := Pixel{0, 0, 255}
+
+	ppm := newBlankPPM(40, 20)
+	ppm.DrawFilledRectangle(Point{0, 0}, 40, 20, color)
+	ppm.Rotate(30, RotateOptions{Filter: FilterBilinear, Expand: true, Background: background})
+	// |40 cos 30| + |20 sin 30| = 44.6, |40 sin 30| + |20 cos 30| = 37.3
+	if ppm.width != 45 || ppm.height != 38 {
+		t.Fatalf("Expanded size wrong: %dx%d", ppm.width, ppm.height)
+	}
+	if ppm.data[19][22] != color || ppm.data[0][0] != background || ppm.data[37][44] != background {
+		t.Error("Rotated content or background wrong")
+	}
+
+	cropped := newBlankPPM(40, 20)
+	cropped.DrawFilledRectangle(Point{0, 0}, 40, 20, color)
+	cropped.Rotate(45, RotateOptions{Filter: FilterBicubic, Background: background})
+	if cropped.width != 40 || cropped.height != 20 || cropped.data[10][20] != color || cropped.data[0][0] != background {
+		t.Error("Cropped rotation wrong")
+	}
+}
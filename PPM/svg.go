@@ -0,0 +1,356 @@
+package Netpbm
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/dada416-lebg/Netpbm/internal/raster"
+)
+
+// SVGStyle décrit le rendu d'un chemin SVG : couleur de remplissage, couleur et épaisseur du contour.
+// Un pointeur nil désactive le remplissage ou le contour correspondant.
+type SVGStyle struct {
+	Fill        *Pixel
+	Stroke      *Pixel
+	StrokeWidth float64
+	FillRule    FillRule
+}
+
+// DrawSVGPath analyse l'attribut d d'un chemin SVG, lui applique la transformation m puis le dessine
+// dans l'image PPM avec le style donné.
+func (ppm *PPM) DrawSVGPath(d string, m Matrix, style SVGStyle) error {
+	path, err := ParseSVGPath(d, m)
+	if err != nil {
+		return err
+	}
+	path.FillRule = style.FillRule
+
+	if style.Fill != nil {
+		ppm.FillPath(path, *style.Fill)
+	}
+	if style.Stroke != nil {
+		// L'épaisseur du trait suit l'échelle moyenne de la transformation
+		width := style.StrokeWidth * math.Sqrt(math.Abs(m.A*m.D-m.B*m.C))
+		ppm.StrokePathWidth(path, width, *style.Stroke)
+	}
+	return nil
+}
+
+// ParseSVGPath analyse l'attribut d d'un chemin SVG (commandes M, L, H, V, C, S, Q, T, A et Z,
+// absolues et relatives) et renvoie le chemin correspondant, transformé par m.
+func ParseSVGPath(d string, m Matrix) (*Path, error) {
+	parser := svgParser{data: d}
+	builder := svgBuilder{path: NewPath(), m: m}
+
+	var command byte
+	for {
+		parser.skipSeparators()
+		if parser.done() {
+			break
+		}
+
+		c := parser.data[parser.pos]
+		if isSVGCommand(c) {
+			if command == 0 && c != 'M' && c != 'm' {
+				return nil, fmt.Errorf("chemin SVG invalide : le chemin doit commencer par M")
+			}
+			command = c
+			parser.pos++
+		} else if command == 0 {
+			return nil, fmt.Errorf("chemin SVG invalide : commande attendue à la position %d", parser.pos)
+		} else if command == 'Z' || command == 'z' {
+			return nil, fmt.Errorf("chemin SVG invalide : valeur inattendue à la position %d", parser.pos)
+		}
+
+		relative := command >= 'a' && command <= 'z'
+		if err := builder.apply(&parser, command, relative); err != nil {
+			return nil, err
+		}
+
+		// Les coordonnées qui suivent un M sont des L implicites
+		if command == 'M' {
+			command = 'L'
+		} else if command == 'm' {
+			command = 'l'
+		}
+	}
+
+	return builder.path, nil
+}
+
+func isSVGCommand(c byte) bool {
+	switch c {
+	case 'M', 'm', 'L', 'l', 'H', 'h', 'V', 'v', 'C', 'c', 'S', 's', 'Q', 'q', 'T', 't', 'A', 'a', 'Z', 'z':
+		return true
+	}
+	return false
+}
+
+// svgParser découpe les données d'un chemin SVG en nombres et en drapeaux.
+type svgParser struct {
+	data string
+	pos  int
+}
+
+func (p *svgParser) done() bool {
+	return p.pos >= len(p.data)
+}
+
+func (p *svgParser) skipSeparators() {
+	for !p.done() {
+		switch p.data[p.pos] {
+		case ' ', '\t', '\n', '\r', '\f', ',':
+			p.pos++
+		default:
+			return
+		}
+	}
+}
+
+// number lit le prochain nombre, en acceptant les formes compactes comme "1.5.5" ou "1-2".
+func (p *svgParser) number() (float64, error) {
+	p.skipSeparators()
+	start := p.pos
+	if !p.done() && (p.data[p.pos] == '+' || p.data[p.pos] == '-') {
+		p.pos++
+	}
+	digits := p.digits()
+	if !p.done() && p.data[p.pos] == '.' {
+		p.pos++
+		digits += p.digits()
+	}
+	if digits == 0 {
+		return 0, fmt.Errorf("chemin SVG invalide : nombre attendu à la position %d", start)
+	}
+	if !p.done() && (p.data[p.pos] == 'e' || p.data[p.pos] == 'E') {
+		save := p.pos
+		p.pos++
+		if !p.done() && (p.data[p.pos] == '+' || p.data[p.pos] == '-') {
+			p.pos++
+		}
+		if p.digits() == 0 {
+			p.pos = save
+		}
+	}
+	return strconv.ParseFloat(p.data[start:p.pos], 64)
+}
+
+func (p *svgParser) digits() int {
+	n := 0
+	for !p.done() && p.data[p.pos] >= '0' && p.data[p.pos] <= '9' {
+		p.pos++
+		n++
+	}
+	return n
+}
+
+// flag lit un drapeau d'arc, qui peut être collé au nombre suivant.
+func (p *svgParser) flag() (bool, error) {
+	p.skipSeparators()
+	if p.done() || (p.data[p.pos] != '0' && p.data[p.pos] != '1') {
+		return false, fmt.Errorf("chemin SVG invalide : drapeau attendu à la position %d", p.pos)
+	}
+	p.pos++
+	return p.data[p.pos-1] == '1', nil
+}
+
+func (p *svgParser) numbers(n int) ([]float64, error) {
+	values := make([]float64, n)
+	for i := range values {
+		v, err := p.number()
+		if err != nil {
+			return nil, err
+		}
+		values[i] = v
+	}
+	return values, nil
+}
+
+// svgBuilder construit le chemin en suivant la position courante dans le repère du SVG.
+type svgBuilder struct {
+	path        *Path
+	m           Matrix
+	current     PointF
+	start       PointF
+	lastControl PointF
+	lastCommand byte
+}
+
+func (b *svgBuilder) apply(p *svgParser, command byte, relative bool) error {
+	origin := PointF{}
+	if relative {
+		origin = b.current
+	}
+	offset := func(x, y float64) PointF {
+		return PointF{X: origin.X + x, Y: origin.Y + y}
+	}
+
+	upper := command
+	if relative {
+		upper -= 'a' - 'A'
+	}
+
+	control := b.current
+	switch upper {
+	case 'M':
+		v, err := p.numbers(2)
+		if err != nil {
+			return err
+		}
+		b.current = offset(v[0], v[1])
+		b.start = b.current
+		b.moveTo(b.current)
+	case 'L':
+		v, err := p.numbers(2)
+		if err != nil {
+			return err
+		}
+		b.current = offset(v[0], v[1])
+		b.lineTo(b.current)
+	case 'H':
+		v, err := p.numbers(1)
+		if err != nil {
+			return err
+		}
+		b.current = PointF{X: origin.X + v[0], Y: b.current.Y}
+		b.lineTo(b.current)
+	case 'V':
+		v, err := p.numbers(1)
+		if err != nil {
+			return err
+		}
+		b.current = PointF{X: b.current.X, Y: origin.Y + v[0]}
+		b.lineTo(b.current)
+	case 'C', 'S':
+		var c1 PointF
+		if upper == 'C' {
+			v, err := p.numbers(2)
+			if err != nil {
+				return err
+			}
+			c1 = offset(v[0], v[1])
+		} else {
+			// Le premier point de contrôle est le reflet du précédent
+			c1 = b.current
+			if b.lastCommand == 'C' || b.lastCommand == 'S' {
+				c1 = PointF{X: 2*b.current.X - b.lastControl.X, Y: 2*b.current.Y - b.lastControl.Y}
+			}
+		}
+		v, err := p.numbers(4)
+		if err != nil {
+			return err
+		}
+		c2 := offset(v[0], v[1])
+		end := offset(v[2], v[3])
+		b.cubicTo(c1, c2, end)
+		control = c2
+		b.current = end
+	case 'Q', 'T':
+		var c PointF
+		if upper == 'Q' {
+			v, err := p.numbers(2)
+			if err != nil {
+				return err
+			}
+			c = offset(v[0], v[1])
+		} else {
+			c = b.current
+			if b.lastCommand == 'Q' || b.lastCommand == 'T' {
+				c = PointF{X: 2*b.current.X - b.lastControl.X, Y: 2*b.current.Y - b.lastControl.Y}
+			}
+		}
+		v, err := p.numbers(2)
+		if err != nil {
+			return err
+		}
+		end := offset(v[0], v[1])
+		p0 := b.current
+		c1 := PointF{X: p0.X + 2.0/3.0*(c.X-p0.X), Y: p0.Y + 2.0/3.0*(c.Y-p0.Y)}
+		c2 := PointF{X: end.X + 2.0/3.0*(c.X-end.X), Y: end.Y + 2.0/3.0*(c.Y-end.Y)}
+		b.cubicTo(c1, c2, end)
+		control = c
+		b.current = end
+	case 'A':
+		radii, err := p.numbers(3)
+		if err != nil {
+			return err
+		}
+		largeArc, err := p.flag()
+		if err != nil {
+			return err
+		}
+		sweep, err := p.flag()
+		if err != nil {
+			return err
+		}
+		v, err := p.numbers(2)
+		if err != nil {
+			return err
+		}
+		end := offset(v[0], v[1])
+		b.arcTo(radii[0], radii[1], radii[2], largeArc, sweep, end)
+		b.current = end
+	case 'Z':
+		b.path.Close()
+		b.current = b.start
+	}
+
+	b.lastCommand = upper
+	b.lastControl = control
+	return nil
+}
+
+func (b *svgBuilder) moveTo(p PointF) {
+	q := b.m.Apply(p)
+	b.path.MoveTo(q.X, q.Y)
+}
+
+func (b *svgBuilder) lineTo(p PointF) {
+	q := b.m.Apply(p)
+	b.path.LineTo(q.X, q.Y)
+}
+
+// cubicTo transforme les points de contrôle avant l'aplatissement, ce qui garde la tolérance
+// exprimée en pixels de l'image quelle que soit l'échelle.
+func (b *svgBuilder) cubicTo(c1, c2, end PointF) {
+	c1, c2, end = b.m.Apply(c1), b.m.Apply(c2), b.m.Apply(end)
+	b.path.CubicTo(c1.X, c1.Y, c2.X, c2.Y, end.X, end.Y)
+}
+
+// arcTo découpe l'arc en cubiques d'au plus 90° afin de pouvoir lui appliquer une transformation affine.
+func (b *svgBuilder) arcTo(rx, ry, rotation float64, largeArc, sweep bool, end PointF) {
+	p0 := b.current
+	if p0 == end {
+		return
+	}
+	rx, ry = math.Abs(rx), math.Abs(ry)
+	if rx == 0 || ry == 0 {
+		b.lineTo(end)
+		return
+	}
+
+	cx, cy, rx, ry, theta, delta := raster.ArcCenter(p0, rx, ry, rotation, largeArc, sweep, end)
+	phi := rotation * math.Pi / 180
+	cosPhi, sinPhi := math.Cos(phi), math.Sin(phi)
+	point := func(t float64) (PointF, PointF) {
+		cos, sin := math.Cos(t), math.Sin(t)
+		pos := PointF{X: cx + rx*cos*cosPhi - ry*sin*sinPhi, Y: cy + rx*cos*sinPhi + ry*sin*cosPhi}
+		deriv := PointF{X: -rx*sin*cosPhi - ry*cos*sinPhi, Y: -rx*sin*sinPhi + ry*cos*cosPhi}
+		return pos, deriv
+	}
+
+	n := int(math.Ceil(math.Abs(delta) / (math.Pi / 2)))
+	step := delta / float64(n)
+	k := 4.0 / 3.0 * math.Tan(step/4)
+	for i := 0; i < n; i++ {
+		t0 := theta + step*float64(i)
+		t1 := t0 + step
+		a, da := point(t0)
+		e, de := point(t1)
+		if i == n-1 {
+			e = end
+		}
+		b.cubicTo(PointF{X: a.X + k*da.X, Y: a.Y + k*da.Y}, PointF{X: e.X - k*de.X, Y: e.Y - k*de.Y}, e)
+	}
+}
@@ -0,0 +1,122 @@
+package Netpbm
+
+import (
+	"math"
+	"testing"
+)
+
+func TestParseSVGPathCommands(t *testing.T) {
+	path, err := ParseSVGPath("M1,2 L3 4 h2 v-1 l-1-1 H0 V0 Z m10,10 1,1 z", IdentityMatrix())
+	if err != nil {
+		t.Fatal(err)
+	}
+	polylines := path.Polylines()
+	if len(polylines) != 2 {
+		t.Fatalf("Wrong number of subpaths: %d", len(polylines))
+	}
+	want := []PointF{{X: 1, Y: 2}, {X: 3, Y: 4}, {X: 5, Y: 4}, {X: 5, Y: 3}, {X: 4, Y: 2}, {X: 0, Y: 2}, {X: 0, Y: 0}, {X: 1, Y: 2}}
+	if len(polylines[0]) != len(want) {
+		t.Fatalf("Wrong number of points: %v", polylines[0])
+	}
+	for i, p := range want {
+		if polylines[0][i] != p {
+			t.Errorf("Point %d wrong: wanted %v got %v", i, p, polylines[0][i])
+		}
+	}
+	// m après un z est relatif au point de départ du sous-chemin fermé
+	second := polylines[1]
+	if second[0] != (PointF{X: 11, Y: 12}) || second[1] != (PointF{X: 12, Y: 13}) {
+		t.Errorf("Relative move after close wrong: %v", second)
+	}
+}
+
+func TestParseSVGPathCompactNumbers(t *testing.T) {
+	path, err := ParseSVGPath("M.5.5l1e1-2.5", IdentityMatrix())
+	if err != nil {
+		t.Fatal(err)
+	}
+	points := path.Polylines()[0]
+	if points[0] != (PointF{X: 0.5, Y: 0.5}) || points[1] != (PointF{X: 10.5, Y: -2}) {
+		t.Errorf("Compact numbers not parsed correctly: %v", points)
+	}
+}
+
+func TestParseSVGPathCurves(t *testing.T) {
+	path, err := ParseSVGPath("M0 0 C0 10 10 10 10 0 S20 -10 20 0 Q25 10 30 0 T40 0", IdentityMatrix())
+	if err != nil {
+		t.Fatal(err)
+	}
+	points := path.Polylines()[0]
+	if points[len(points)-1] != (PointF{X: 40, Y: 0}) {
+		t.Errorf("Path does not end on its last point: %v", points[len(points)-1])
+	}
+	// La réflexion du S donne une bosse symétrique vers le haut
+	minY := 0.0
+	for _, p := range points {
+		if p.X > 10 && p.X < 20 {
+			minY = math.Min(minY, p.Y)
+		}
+	}
+	if math.Abs(minY+7.5) > 0.5 {
+		t.Errorf("Smooth curve reflection wrong: min y %f", minY)
+	}
+}
+
+func TestParseSVGPathArc(t *testing.T) {
+	// Un cercle complet de rayon 5 centré en (10, 10), mis à l'échelle par 2
+	path, err := ParseSVGPath("M5 10 a5 5 0 1 0 10 0 a5 5 0 1 0-10 0z", ScaleMatrix(2, 2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, p := range path.Polylines()[0] {
+		if d := math.Hypot(p.X-20, p.Y-20); math.Abs(d-10) > 0.3 {
+			t.Errorf("Point %v is not on the scaled circle", p)
+		}
+	}
+}
+
+func TestParseSVGPathErrors(t *testing.T) {
+	for _, d := range []string{"L1 1", "M1", "M0 0 A1 1 0 2 0 1 1", "M0 0 Z 1 1"} {
+		if _, err := ParseSVGPath(d, IdentityMatrix()); err == nil {
+			t.Errorf("Expected an error for %q", d)
+		}
+	}
+}
+
+func TestPPMDrawSVGPath(t *testing.T) {
+	ppm := newBlankPPM(20, 20)
+	red := Pixel{255, 0, 0}
+	black := Pixel{0, 0, 0}
+	err := ppm.DrawSVGPath("M0 0h4v4h-4z", TranslateMatrix(2, 2).Scale(3, 3), SVGStyle{Fill: &red})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if countPixels(ppm, red) != 144 {
+		t.Errorf("Wrong filled area: %d", countPixels(ppm, red))
+	}
+	if ppm.data[2][2] != red || ppm.data[13][13] != red || ppm.data[14][14] == red {
+		t.Error("Transformed square not at the right place")
+	}
+
+	ppm = newBlankPPM(20, 20)
+	err = ppm.DrawSVGPath("M2 2H17V17H2Z", IdentityMatrix(), SVGStyle{Fill: &red, Stroke: &black, StrokeWidth: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ppm.data[2][10] != black || ppm.data[10][10] != red {
+		t.Error("Fill and stroke not drawn correctly")
+	}
+}
+
+func TestMatrixInvert(t *testing.T) {
+	m := TranslateMatrix(3, -2).Rotate(30).Scale(2, 0.5)
+	inv, ok := m.Invert()
+	if !ok {
+		t.Fatal("Matrix should be invertible")
+	}
+	p := PointF{X: 7, Y: -4}
+	q := inv.Apply(m.Apply(p))
+	if math.Abs(q.X-p.X) > 1e-9 || math.Abs(q.Y-p.Y) > 1e-9 {
+		t.Errorf("Inverse wrong: got %v", q)
+	}
+}
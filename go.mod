@@ -1,5 +1,3 @@
 module github.com/dada416-lebg/Netpbm
 
 go 1.21.3
-
-require github.com/aquilax/go-perlin v1.1.0
@@ -0,0 +1,427 @@
+// Package font regroupe les polices bitmap communes aux trois formats : police intégrée, chargement
+// des polices BDF, mesure et mise en page du texte. Chaque paquet d'images n'a plus qu'à allumer
+// les pixels produits par Render.
+package font
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Glyph représente le dessin bitmap d'un caractère.
+// Comme dans le format BDF, XOffset et YOffset placent le coin inférieur gauche du bitmap
+// par rapport à l'origine du caractère, située sur la ligne de base.
+type Glyph struct {
+	Width, Height    int
+	XOffset, YOffset int
+	Advance          int
+	Bitmap           [][]bool
+}
+
+// Font est une police bitmap.
+type Font struct {
+	Glyphs  map[rune]*Glyph
+	Ascent  int
+	Descent int
+	// Default est le caractère utilisé pour les caractères absents de la police.
+	Default rune
+}
+
+// TextAlign définit l'alignement horizontal des lignes de texte.
+type TextAlign int
+
+const (
+	AlignLeft TextAlign = iota
+	AlignCenter
+	AlignRight
+)
+
+// TextOptions regroupe les paramètres de rendu du texte.
+type TextOptions struct {
+	// Font est la police utilisée ; la police intégrée si nil.
+	Font *Font
+	// Align indique si le point de référence est le bord gauche, le centre ou le bord droit de chaque ligne.
+	Align TextAlign
+	// Scale est un facteur d'agrandissement entier (1 par défaut).
+	Scale int
+	// LineSpacing est l'espace supplémentaire, en pixels de police, entre deux lignes.
+	LineSpacing int
+}
+
+// glyph renvoie le dessin d'un caractère, ou celui du caractère par défaut.
+func (f *Font) glyph(r rune) *Glyph {
+	if g, ok := f.Glyphs[r]; ok {
+		return g
+	}
+	return f.Glyphs[f.Default]
+}
+
+// LineHeight renvoie la hauteur d'une ligne de texte, en pixels de police.
+func (f *Font) LineHeight() int {
+	return f.Ascent + f.Descent
+}
+
+// lineWidth renvoie la largeur d'une ligne de texte, en pixels de police.
+func (f *Font) lineWidth(line string) int {
+	width := 0
+	for _, r := range line {
+		if g := f.glyph(r); g != nil {
+			width += g.Advance
+		}
+	}
+	return width
+}
+
+// normalized complète les options de texte avec leurs valeurs par défaut.
+func (opts TextOptions) normalized() TextOptions {
+	if opts.Font == nil {
+		opts.Font = DefaultFont()
+	}
+	if opts.Scale < 1 {
+		opts.Scale = 1
+	}
+	return opts
+}
+
+// MeasureText renvoie la largeur et la hauteur, en pixels, du texte rendu avec les options données.
+func MeasureText(text string, opts TextOptions) (int, int) {
+	opts = opts.normalized()
+	lines := strings.Split(text, "\n")
+	width := 0
+	for _, line := range lines {
+		if w := opts.Font.lineWidth(line); w > width {
+			width = w
+		}
+	}
+	height := len(lines)*opts.Font.LineHeight() + (len(lines)-1)*opts.LineSpacing
+	return width * opts.Scale, height * opts.Scale
+}
+
+// Render appelle plot pour chaque pixel allumé du texte.
+// Le point (x, y) est le haut de la première ligne ; sa coordonnée x dépend de l'alignement.
+func Render(x, y int, text string, opts TextOptions, plot func(x, y int)) {
+	opts = opts.normalized()
+	font, scale := opts.Font, opts.Scale
+
+	top := y
+	for _, line := range strings.Split(text, "\n") {
+		penX := x
+		switch opts.Align {
+		case AlignCenter:
+			penX -= font.lineWidth(line) * scale / 2
+		case AlignRight:
+			penX -= font.lineWidth(line) * scale
+		}
+		baseline := top + font.Ascent*scale
+
+		for _, r := range line {
+			g := font.glyph(r)
+			if g == nil {
+				continue
+			}
+			// Coin supérieur gauche du bitmap du caractère
+			x0 := penX + g.XOffset*scale
+			y0 := baseline - (g.YOffset+g.Height)*scale
+			for gy, row := range g.Bitmap {
+				for gx, on := range row {
+					if !on {
+						continue
+					}
+					for sy := 0; sy < scale; sy++ {
+						for sx := 0; sx < scale; sx++ {
+							plot(x0+gx*scale+sx, y0+gy*scale+sy)
+						}
+					}
+				}
+			}
+			penX += g.Advance * scale
+		}
+
+		top += (font.LineHeight() + opts.LineSpacing) * scale
+	}
+}
+
+// LoadBDF charge une police au format BDF (Glyph Bitmap Distribution Format) depuis un fichier.
+func LoadBDF(filename string) (*Font, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return ParseBDF(file)
+}
+
+// ParseBDF lit une police au format BDF.
+func ParseBDF(r io.Reader) (*Font, error) {
+	scanner := bufio.NewScanner(r)
+	font := &Font{Glyphs: make(map[rune]*Glyph), Default: -1}
+
+	var (
+		boxHeight, boxYOffset int
+		glyph                 *Glyph
+		encoding              = -1
+		inBitmap              bool
+		lineNumber            int
+	)
+
+	atoi := func(fields []string, count int) ([]int, error) {
+		if len(fields) < count+1 {
+			return nil, fmt.Errorf("ligne %d : %s attend %d valeurs", lineNumber, fields[0], count)
+		}
+		values := make([]int, count)
+		for i := range values {
+			v, err := strconv.Atoi(fields[i+1])
+			if err != nil {
+				return nil, fmt.Errorf("ligne %d : valeur invalide %q", lineNumber, fields[i+1])
+			}
+			values[i] = v
+		}
+		return values, nil
+	}
+
+	for scanner.Scan() {
+		lineNumber++
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+
+		if inBitmap {
+			if fields[0] == "ENDCHAR" {
+				inBitmap = false
+				if glyph.Advance == 0 {
+					glyph.Advance = glyph.XOffset + glyph.Width
+				}
+				if encoding >= 0 {
+					font.Glyphs[rune(encoding)] = glyph
+				}
+				glyph = nil
+				continue
+			}
+			// Chaque ligne du bitmap est un nombre hexadécimal aligné à gauche sur un multiple de 8 bits
+			row := make([]bool, glyph.Width)
+			for x := 0; x < glyph.Width; x++ {
+				digit := x / 4
+				if digit >= len(fields[0]) {
+					break
+				}
+				nibble, err := strconv.ParseUint(fields[0][digit:digit+1], 16, 8)
+				if err != nil {
+					return nil, fmt.Errorf("ligne %d : bitmap invalide %q", lineNumber, fields[0])
+				}
+				row[x] = nibble&(8>>(uint(x)%4)) != 0
+			}
+			glyph.Bitmap = append(glyph.Bitmap, row)
+			continue
+		}
+
+		switch fields[0] {
+		case "FONTBOUNDINGBOX":
+			v, err := atoi(fields, 4)
+			if err != nil {
+				return nil, err
+			}
+			boxHeight, boxYOffset = v[1], v[3]
+		case "FONT_ASCENT":
+			v, err := atoi(fields, 1)
+			if err != nil {
+				return nil, err
+			}
+			font.Ascent = v[0]
+		case "FONT_DESCENT":
+			v, err := atoi(fields, 1)
+			if err != nil {
+				return nil, err
+			}
+			font.Descent = v[0]
+		case "DEFAULT_CHAR":
+			v, err := atoi(fields, 1)
+			if err != nil {
+				return nil, err
+			}
+			font.Default = rune(v[0])
+		case "STARTCHAR":
+			glyph = &Glyph{}
+			encoding = -1
+		case "ENCODING":
+			v, err := atoi(fields, 1)
+			if err != nil {
+				return nil, err
+			}
+			encoding = v[0]
+		case "DWIDTH":
+			v, err := atoi(fields, 1)
+			if err != nil {
+				return nil, err
+			}
+			if glyph != nil {
+				glyph.Advance = v[0]
+			}
+		case "BBX":
+			v, err := atoi(fields, 4)
+			if err != nil {
+				return nil, err
+			}
+			if glyph != nil {
+				glyph.Width, glyph.Height, glyph.XOffset, glyph.YOffset = v[0], v[1], v[2], v[3]
+			}
+		case "BITMAP":
+			if glyph == nil {
+				return nil, fmt.Errorf("ligne %d : BITMAP en dehors d'un caractère", lineNumber)
+			}
+			inBitmap = true
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if inBitmap {
+		return nil, fmt.Errorf("fin de fichier inattendue dans un caractère")
+	}
+	if len(font.Glyphs) == 0 {
+		return nil, fmt.Errorf("aucun caractère trouvé dans la police BDF")
+	}
+
+	// Sans FONT_ASCENT ni FONT_DESCENT, utiliser la boîte englobante de la police
+	if font.Ascent == 0 && font.Descent == 0 {
+		font.Ascent = boxHeight + boxYOffset
+		font.Descent = -boxYOffset
+	}
+	if _, ok := font.Glyphs[font.Default]; !ok {
+		if _, ok := font.Glyphs['?']; ok {
+			font.Default = '?'
+		} else {
+			font.Default = ' '
+		}
+	}
+	return font, nil
+}
+
+var (
+	defaultFont     *Font
+	defaultFontOnce sync.Once
+)
+
+// DefaultFont renvoie la police bitmap intégrée : 5x7 pixels avec jambages, couvrant l'ASCII imprimable.
+func DefaultFont() *Font {
+	defaultFontOnce.Do(func() {
+		font := &Font{Glyphs: make(map[rune]*Glyph), Ascent: 7, Descent: 1, Default: '?'}
+		for i, columns := range defaultFontData {
+			glyph := &Glyph{Width: 5, Height: 8, YOffset: -1, Advance: 6, Bitmap: make([][]bool, 8)}
+			for y := 0; y < 8; y++ {
+				glyph.Bitmap[y] = make([]bool, 5)
+				for x := 0; x < 5; x++ {
+					glyph.Bitmap[y][x] = columns[x]&(1<<uint(y)) != 0
+				}
+			}
+			font.Glyphs[rune(' '+i)] = glyph
+		}
+		defaultFont = font
+	})
+	return defaultFont
+}
+
+// defaultFontData contient les caractères ASCII de ' ' à '~', colonne par colonne ; le bit 0 est la ligne du haut.
+var defaultFontData = [95][5]byte{
+	{0x00, 0x00, 0x00, 0x00, 0x00}, // ' '
+	{0x00, 0x00, 0x5F, 0x00, 0x00}, // '!'
+	{0x00, 0x07, 0x00, 0x07, 0x00}, // '"'
+	{0x14, 0x7F, 0x14, 0x7F, 0x14}, // '#'
+	{0x24, 0x2A, 0x7F, 0x2A, 0x12}, // '$'
+	{0x23, 0x13, 0x08, 0x64, 0x62}, // '%'
+	{0x36, 0x49, 0x56, 0x20, 0x50}, // '&'
+	{0x00, 0x08, 0x07, 0x03, 0x00}, // '\''
+	{0x00, 0x1C, 0x22, 0x41, 0x00}, // '('
+	{0x00, 0x41, 0x22, 0x1C, 0x00}, // ')'
+	{0x2A, 0x1C, 0x7F, 0x1C, 0x2A}, // '*'
+	{0x08, 0x08, 0x3E, 0x08, 0x08}, // '+'
+	{0x00, 0x80, 0x70, 0x30, 0x00}, // ','
+	{0x08, 0x08, 0x08, 0x08, 0x08}, // '-'
+	{0x00, 0x00, 0x60, 0x60, 0x00}, // '.'
+	{0x20, 0x10, 0x08, 0x04, 0x02}, // '/'
+	{0x3E, 0x51, 0x49, 0x45, 0x3E}, // '0'
+	{0x00, 0x42, 0x7F, 0x40, 0x00}, // '1'
+	{0x42, 0x61, 0x51, 0x49, 0x46}, // '2'
+	{0x21, 0x41, 0x45, 0x4B, 0x31}, // '3'
+	{0x18, 0x14, 0x12, 0x7F, 0x10}, // '4'
+	{0x27, 0x45, 0x45, 0x45, 0x39}, // '5'
+	{0x3C, 0x4A, 0x49, 0x49, 0x31}, // '6'
+	{0x41, 0x21, 0x11, 0x09, 0x07}, // '7'
+	{0x36, 0x49, 0x49, 0x49, 0x36}, // '8'
+	{0x46, 0x49, 0x49, 0x29, 0x1E}, // '9'
+	{0x00, 0x00, 0x14, 0x00, 0x00}, // ':'
+	{0x00, 0x40, 0x34, 0x00, 0x00}, // ';'
+	{0x00, 0x08, 0x14, 0x22, 0x41}, // '<'
+	{0x14, 0x14, 0x14, 0x14, 0x14}, // '='
+	{0x00, 0x41, 0x22, 0x14, 0x08}, // '>'
+	{0x02, 0x01, 0x59, 0x09, 0x06}, // '?'
+	{0x3E, 0x41, 0x5D, 0x59, 0x4E}, // '@'
+	{0x7C, 0x12, 0x11, 0x12, 0x7C}, // 'A'
+	{0x7F, 0x49, 0x49, 0x49, 0x36}, // 'B'
+	{0x3E, 0x41, 0x41, 0x41, 0x22}, // 'C'
+	{0x7F, 0x41, 0x41, 0x41, 0x3E}, // 'D'
+	{0x7F, 0x49, 0x49, 0x49, 0x41}, // 'E'
+	{0x7F, 0x09, 0x09, 0x09, 0x01}, // 'F'
+	{0x3E, 0x41, 0x41, 0x51, 0x73}, // 'G'
+	{0x7F, 0x08, 0x08, 0x08, 0x7F}, // 'H'
+	{0x00, 0x41, 0x7F, 0x41, 0x00}, // 'I'
+	{0x20, 0x40, 0x41, 0x3F, 0x01}, // 'J'
+	{0x7F, 0x08, 0x14, 0x22, 0x41}, // 'K'
+	{0x7F, 0x40, 0x40, 0x40, 0x40}, // 'L'
+	{0x7F, 0x02, 0x1C, 0x02, 0x7F}, // 'M'
+	{0x7F, 0x04, 0x08, 0x10, 0x7F}, // 'N'
+	{0x3E, 0x41, 0x41, 0x41, 0x3E}, // 'O'
+	{0x7F, 0x09, 0x09, 0x09, 0x06}, // 'P'
+	{0x3E, 0x41, 0x51, 0x21, 0x5E}, // 'Q'
+	{0x7F, 0x09, 0x19, 0x29, 0x46}, // 'R'
+	{0x26, 0x49, 0x49, 0x49, 0x32}, // 'S'
+	{0x03, 0x01, 0x7F, 0x01, 0x03}, // 'T'
+	{0x3F, 0x40, 0x40, 0x40, 0x3F}, // 'U'
+	{0x1F, 0x20, 0x40, 0x20, 0x1F}, // 'V'
+	{0x3F, 0x40, 0x38, 0x40, 0x3F}, // 'W'
+	{0x63, 0x14, 0x08, 0x14, 0x63}, // 'X'
+	{0x03, 0x04, 0x78, 0x04, 0x03}, // 'Y'
+	{0x61, 0x59, 0x49, 0x4D, 0x43}, // 'Z'
+	{0x00, 0x7F, 0x41, 0x41, 0x41}, // '['
+	{0x02, 0x04, 0x08, 0x10, 0x20}, // '\\'
+	{0x00, 0x41, 0x41, 0x41, 0x7F}, // ']'
+	{0x04, 0x02, 0x01, 0x02, 0x04}, // '^'
+	{0x40, 0x40, 0x40, 0x40, 0x40}, // '_'
+	{0x00, 0x03, 0x07, 0x08, 0x00}, // '`'
+	{0x20, 0x54, 0x54, 0x78, 0x40}, // 'a'
+	{0x7F, 0x28, 0x44, 0x44, 0x38}, // 'b'
+	{0x38, 0x44, 0x44, 0x44, 0x28}, // 'c'
+	{0x38, 0x44, 0x44, 0x28, 0x7F}, // 'd'
+	{0x38, 0x54, 0x54, 0x54, 0x18}, // 'e'
+	{0x00, 0x08, 0x7E, 0x09, 0x02}, // 'f'
+	{0x18, 0xA4, 0xA4, 0x9C, 0x78}, // 'g'
+	{0x7F, 0x08, 0x04, 0x04, 0x78}, // 'h'
+	{0x00, 0x44, 0x7D, 0x40, 0x00}, // 'i'
+	{0x20, 0x40, 0x40, 0x3D, 0x00}, // 'j'
+	{0x7F, 0x10, 0x28, 0x44, 0x00}, // 'k'
+	{0x00, 0x41, 0x7F, 0x40, 0x00}, // 'l'
+	{0x7C, 0x04, 0x78, 0x04, 0x78}, // 'm'
+	{0x7C, 0x08, 0x04, 0x04, 0x78}, // 'n'
+	{0x38, 0x44, 0x44, 0x44, 0x38}, // 'o'
+	{0xFC, 0x24, 0x24, 0x24, 0x18}, // 'p'
+	{0x18, 0x24, 0x24, 0x24, 0xFC}, // 'q'
+	{0x7C, 0x08, 0x04, 0x04, 0x08}, // 'r'
+	{0x48, 0x54, 0x54, 0x54, 0x24}, // 's'
+	{0x04, 0x04, 0x3F, 0x44, 0x24}, // 't'
+	{0x3C, 0x40, 0x40, 0x20, 0x7C}, // 'u'
+	{0x1C, 0x20, 0x40, 0x20, 0x1C}, // 'v'
+	{0x3C, 0x40, 0x30, 0x40, 0x3C}, // 'w'
+	{0x44, 0x28, 0x10, 0x28, 0x44}, // 'x'
+	{0x4C, 0x90, 0x90, 0x90, 0x7C}, // 'y'
+	{0x44, 0x64, 0x54, 0x4C, 0x44}, // 'z'
+	{0x00, 0x08, 0x36, 0x41, 0x00}, // '{'
+	{0x00, 0x00, 0x77, 0x00, 0x00}, // '|'
+	{0x00, 0x41, 0x36, 0x08, 0x00}, // '}'
+	{0x02, 0x01, 0x02, 0x04, 0x02}, // '~'
+}
@@ -0,0 +1,28 @@
+package font
+
+import "testing"
+
+func TestRenderWithinMeasure(t *testing.T) {
+	opts := TextOptions{Scale: 2}
+	width, height := MeasureText("Ag\nxy", opts)
+	if width <= 0 || height != 2*2*DefaultFont().LineHeight() {
+		t.Fatalf("MeasureText: got %dx%d", width, height)
+	}
+	count := 0
+	Render(0, 0, "Ag\nxy", opts, func(x, y int) {
+		count++
+		if x < 0 || x >= width || y < 0 || y >= height {
+			t.Errorf("Pixel (%d, %d) outside %dx%d", x, y, width, height)
+		}
+	})
+	if count == 0 {
+		t.Error("Render plotted no pixel")
+	}
+	right := 0
+	Render(10, 0, "A", TextOptions{Align: AlignRight}, func(x, y int) {
+		right = max(right, x)
+	})
+	if right >= 10 {
+		t.Errorf("AlignRight: rightmost pixel %d should be left of 10", right)
+	}
+}
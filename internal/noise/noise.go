@@ -0,0 +1,178 @@
+// Package noise génère les bruits procéduraux (Perlin, valeur, Worley) communs aux formats PGM et PPM.
+package noise
+
+import (
+	"math"
+	"math/rand"
+)
+
+// Type choisit la fonction de bruit de base.
+type Type int
+
+const (
+	// Perlin est le bruit de gradient de Perlin.
+	Perlin Type = iota
+	// Value interpole des valeurs aléatoires placées sur une grille.
+	Value
+	// Worley (ou bruit cellulaire) est la distance au point caractéristique le plus proche.
+	Worley
+)
+
+// Options regroupe les paramètres d'un générateur de bruit fractal.
+type Options struct {
+	Type Type
+	// Seed détermine entièrement le bruit : une même graine donne toujours la même image.
+	Seed int64
+	// Octaves est le nombre de couches de bruit superposées.
+	Octaves int
+	// Persistence multiplie l'amplitude d'une octave à la suivante.
+	Persistence float64
+	// Lacunarity multiplie la fréquence d'une octave à la suivante.
+	Lacunarity float64
+	// Scale est la taille en pixels d'une cellule de la première octave.
+	Scale float64
+	// OffsetX et OffsetY décalent l'échantillonnage, en pixels.
+	OffsetX, OffsetY float64
+}
+
+// DefaultOptions renvoie des paramètres par défaut : bruit de Perlin d'une octave, graine 42, cellules de 50 pixels.
+func DefaultOptions() Options {
+	return Options{
+		Type:        Perlin,
+		Seed:        42,
+		Octaves:     1,
+		Persistence: 0.5,
+		Lacunarity:  2,
+		Scale:       50,
+	}
+}
+
+// Noise est un générateur de bruit déterministe.
+type Noise struct {
+	options Options
+	perm    [512]int
+}
+
+// New crée un générateur de bruit. Les paramètres nuls ou négatifs prennent la valeur par défaut.
+func New(options Options) *Noise {
+	defaults := DefaultOptions()
+	if options.Octaves <= 0 {
+		options.Octaves = defaults.Octaves
+	}
+	if options.Persistence <= 0 {
+		options.Persistence = defaults.Persistence
+	}
+	if options.Lacunarity <= 0 {
+		options.Lacunarity = defaults.Lacunarity
+	}
+	if options.Scale <= 0 {
+		options.Scale = defaults.Scale
+	}
+
+	n := &Noise{options: options}
+	// Table de permutation dupliquée pour éviter les débordements d'indice
+	perm := rand.New(rand.NewSource(options.Seed)).Perm(256)
+	for i := 0; i < 512; i++ {
+		n.perm[i] = perm[i&255]
+	}
+	return n
+}
+
+// At renvoie la valeur du bruit au pixel (x, y), normalisée dans [0, 1].
+func (n *Noise) At(x, y float64) float64 {
+	x = (x + n.options.OffsetX) / n.options.Scale
+	y = (y + n.options.OffsetY) / n.options.Scale
+
+	sum, norm := 0.0, 0.0
+	amplitude, frequency := 1.0, 1.0
+	for octave := 0; octave < n.options.Octaves; octave++ {
+		// Décaler chaque octave pour que les grilles ne coïncident pas à l'origine
+		shift := float64(octave) * 31.7
+		sum += amplitude * n.base(x*frequency+shift, y*frequency+shift)
+		norm += amplitude
+		amplitude *= n.options.Persistence
+		frequency *= n.options.Lacunarity
+	}
+	return math.Max(0, math.Min(1, (sum/norm+1)/2))
+}
+
+// base renvoie le bruit de base au point (x, y), dans [-1, 1].
+func (n *Noise) base(x, y float64) float64 {
+	switch n.options.Type {
+	case Value:
+		return n.value(x, y)
+	case Worley:
+		return n.worley(x, y)
+	}
+	return n.perlin(x, y)
+}
+
+// hash renvoie un entier pseudo-aléatoire entre 0 et 255 associé au nœud (ix, iy) de la grille.
+func (n *Noise) hash(ix, iy int) int {
+	return n.perm[n.perm[ix&255]+iy&255]
+}
+
+// perlin calcule le bruit de gradient. Les gradients diagonaux (±1, ±1) donnent une sortie dans [-1, 1].
+func (n *Noise) perlin(x, y float64) float64 {
+	x0, y0 := math.Floor(x), math.Floor(y)
+	ix, iy := int(x0), int(y0)
+	fx, fy := x-x0, y-y0
+
+	grad := func(h int, dx, dy float64) float64 {
+		switch h & 3 {
+		case 0:
+			return dx + dy
+		case 1:
+			return -dx + dy
+		case 2:
+			return dx - dy
+		}
+		return -dx - dy
+	}
+
+	u, v := fade(fx), fade(fy)
+	a := lerp(grad(n.hash(ix, iy), fx, fy), grad(n.hash(ix+1, iy), fx-1, fy), u)
+	b := lerp(grad(n.hash(ix, iy+1), fx, fy-1), grad(n.hash(ix+1, iy+1), fx-1, fy-1), u)
+	return math.Max(-1, math.Min(1, lerp(a, b, v)))
+}
+
+// value interpole les valeurs aléatoires des quatre nœuds voisins.
+func (n *Noise) value(x, y float64) float64 {
+	x0, y0 := math.Floor(x), math.Floor(y)
+	ix, iy := int(x0), int(y0)
+	u, v := fade(x-x0), fade(y-y0)
+
+	node := func(ix, iy int) float64 {
+		return float64(n.hash(ix, iy))/127.5 - 1
+	}
+	a := lerp(node(ix, iy), node(ix+1, iy), u)
+	b := lerp(node(ix, iy+1), node(ix+1, iy+1), u)
+	return lerp(a, b, v)
+}
+
+// worley renvoie la distance au point caractéristique le plus proche, chaque cellule de la grille
+// contenant un point. La distance, bornée à 1, est ramenée dans [-1, 1].
+func (n *Noise) worley(x, y float64) float64 {
+	x0, y0 := math.Floor(x), math.Floor(y)
+	ix, iy := int(x0), int(y0)
+
+	best := math.Inf(1)
+	for cy := iy - 1; cy <= iy+1; cy++ {
+		for cx := ix - 1; cx <= ix+1; cx++ {
+			h := n.hash(cx, cy)
+			px := float64(cx) + float64(h)/256
+			py := float64(cy) + float64(n.hash(cy+h, cx))/256
+			best = math.Min(best, math.Hypot(x-px, y-py))
+		}
+	}
+	return 2*math.Min(best, 1) - 1
+}
+
+// fade est la courbe d'interpolation 6t⁵ - 15t⁴ + 10t³ de Perlin.
+func fade(t float64) float64 {
+	return t * t * t * (t*(t*6-15) + 10)
+}
+
+func lerp(a, b, t float64) float64 {
+	return a + (b-a)*t
+}
@@ -0,0 +1,22 @@
+package noise
+
+import "testing"
+
+func TestNoiseRangeAndDefaults(t *testing.T) {
+	for _, noiseType := range []Type{Perlin, Value, Worley} {
+		// Les paramètres nuls prennent la valeur par défaut
+		n := New(Options{Type: noiseType, Seed: 7})
+		same := New(Options{Type: noiseType, Seed: 7, Octaves: 1, Persistence: 0.5, Lacunarity: 2, Scale: 50})
+		for y := 0.0; y < 100; y += 3.7 {
+			for x := 0.0; x < 100; x += 2.9 {
+				v := n.At(x, y)
+				if v < 0 || v > 1 {
+					t.Fatalf("Type %d: value %v out of [0, 1] at (%v, %v)", noiseType, v, x, y)
+				}
+				if v != same.At(x, y) {
+					t.Fatalf("Type %d: zero options should use the defaults", noiseType)
+				}
+			}
+		}
+	}
+}
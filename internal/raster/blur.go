@@ -0,0 +1,62 @@
+package raster
+
+import "math"
+
+// GaussianKernel renvoie le noyau gaussien séparable d'écart type sigma, de rayon ceil(3 × sigma)
+// et de somme 1.
+func GaussianKernel(sigma float64) Kernel {
+	radius := int(math.Ceil(3 * sigma))
+	weights := make([]float64, 2*radius+1)
+	sum := 0.0
+	for i := range weights {
+		d := float64(i - radius)
+		weights[i] = math.Exp(-d * d / (2 * sigma * sigma))
+		sum += weights[i]
+	}
+	for i := range weights {
+		weights[i] /= sum
+	}
+	return NewSeparableKernel(weights, weights)
+}
+
+// UnsharpPlane renvoie le plan accentué à partir du plan d'origine et de son flou.
+func UnsharpPlane(plane, blurred []float64, amount, threshold float64) []float64 {
+	result := make([]float64, len(plane))
+	for i, v := range plane {
+		result[i] = v
+		if diff := v - blurred[i]; math.Abs(diff) >= threshold {
+			result[i] += amount * diff
+		}
+	}
+	return result
+}
+
+// BoxBlurPlane applique le flou moyenneur de rayon radius à un plan width × height, en deux passes.
+func BoxBlurPlane(src []float64, width, height, radius int) []float64 {
+	tmp := make([]float64, len(src))
+	for y := 0; y < height; y++ {
+		boxBlurLine(src, tmp, y*width, 1, width, radius)
+	}
+	dst := make([]float64, len(src))
+	for x := 0; x < width; x++ {
+		boxBlurLine(tmp, dst, x, width, height, radius)
+	}
+	return dst
+}
+
+// boxBlurLine floute les n valeurs src[offset + i × stride] avec une somme glissante.
+func boxBlurLine(src, dst []float64, offset, stride, n, radius int) {
+	at := func(i int) float64 {
+		i, _ = EdgeIndex(i, n, EdgeReplicate)
+		return src[offset+i*stride]
+	}
+	size := float64(2*radius + 1)
+	sum := 0.0
+	for i := -radius; i <= radius; i++ {
+		sum += at(i)
+	}
+	for i := 0; i < n; i++ {
+		dst[offset+i*stride] = sum / size
+		sum += at(i+radius+1) - at(i-radius)
+	}
+}
@@ -0,0 +1,23 @@
+package raster
+
+import (
+	"math"
+	"testing"
+)
+
+func TestBoxBlurPlane(t *testing.T) {
+	plane := make([]float64, 5*5)
+	plane[12] = 9
+	result := BoxBlurPlane(plane, 5, 5, 1)
+	for y := 0; y < 5; y++ {
+		for x := 0; x < 5; x++ {
+			want := 0.0
+			if x >= 1 && x <= 3 && y >= 1 && y <= 3 {
+				want = 1
+			}
+			if got := result[y*5+x]; math.Abs(got-want) > 1e-9 {
+				t.Errorf("(%d, %d): wanted %v got %v", x, y, want, got)
+			}
+		}
+	}
+}
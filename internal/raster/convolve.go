@@ -0,0 +1,201 @@
+package raster
+
+import (
+	"fmt"
+	"math"
+)
+
+// Kernel est un noyau de convolution de Width × Height coefficients, rangés ligne par ligne.
+// Le pixel traité est placé au centre du noyau (Width/2, Height/2).
+type Kernel struct {
+	Width, Height int
+	Values        []float64
+	// row et column sont les facteurs d'un noyau séparable (Values = column × row), nil sinon.
+	row, column []float64
+}
+
+// NewKernel crée un noyau à partir de ses lignes, qui doivent toutes avoir la même longueur.
+// Un noyau séparable est reconnu automatiquement et appliqué en deux passes, une horizontale et une verticale.
+func NewKernel(rows [][]float64) Kernel {
+	k := Kernel{Height: len(rows)}
+	if k.Height > 0 {
+		k.Width = len(rows[0])
+	}
+	k.Values = make([]float64, 0, k.Width*k.Height)
+	for _, row := range rows {
+		if len(row) != k.Width {
+			fmt.Println("Les lignes du noyau n'ont pas toutes la même longueur.")
+			return Kernel{}
+		}
+		k.Values = append(k.Values, row...)
+	}
+	k.row, k.column = separate(k)
+	return k
+}
+
+// NewSeparableKernel crée le noyau produit d'une colonne et d'une ligne : la ligne est appliquée
+// horizontalement puis la colonne verticalement.
+func NewSeparableKernel(row, column []float64) Kernel {
+	k := Kernel{Width: len(row), Height: len(column), Values: make([]float64, len(row)*len(column))}
+	for y, cv := range column {
+		for x, rv := range row {
+			k.Values[y*k.Width+x] = cv * rv
+		}
+	}
+	k.row = append([]float64(nil), row...)
+	k.column = append([]float64(nil), column...)
+	return k
+}
+
+// separate cherche une décomposition du noyau en produit d'une colonne et d'une ligne.
+func separate(k Kernel) (row, column []float64) {
+	// Pivot : le coefficient le plus grand en valeur absolue
+	pivot := -1
+	for i, v := range k.Values {
+		if pivot < 0 || math.Abs(v) > math.Abs(k.Values[pivot]) {
+			pivot = i
+		}
+	}
+	if pivot < 0 || k.Values[pivot] == 0 {
+		return nil, nil
+	}
+	py, px := pivot/k.Width, pivot%k.Width
+	row = make([]float64, k.Width)
+	column = make([]float64, k.Height)
+	for x := range row {
+		row[x] = k.Values[py*k.Width+x] / k.Values[pivot]
+	}
+	for y := range column {
+		column[y] = k.Values[y*k.Width+px]
+	}
+	for y := range column {
+		for x := range row {
+			if math.Abs(column[y]*row[x]-k.Values[y*k.Width+x]) > 1e-9 {
+				return nil, nil
+			}
+		}
+	}
+	return row, column
+}
+
+// Separable indique si le noyau est appliqué en deux passes.
+func (k Kernel) Separable() bool {
+	return k.row != nil
+}
+
+// Sum renvoie la somme des coefficients du noyau.
+func (k Kernel) Sum() float64 {
+	sum := 0.0
+	for _, v := range k.Values {
+		sum += v
+	}
+	return sum
+}
+
+// Normalized renvoie le noyau divisé par la somme de ses coefficients, afin de conserver la luminosité
+// moyenne. Un noyau de somme nulle est renvoyé tel quel.
+func (k Kernel) Normalized() Kernel {
+	sum := k.Sum()
+	if sum == 0 {
+		return k
+	}
+	n := Kernel{Width: k.Width, Height: k.Height, Values: make([]float64, len(k.Values))}
+	for i, v := range k.Values {
+		n.Values[i] = v / sum
+	}
+	if k.Separable() {
+		n.row = make([]float64, len(k.row))
+		for i, v := range k.row {
+			n.row[i] = v / sum
+		}
+		n.column = append([]float64(nil), k.column...)
+	}
+	return n
+}
+
+// BoxKernel renvoie le noyau moyenneur de taille size × size.
+func BoxKernel(size int) Kernel {
+	row := make([]float64, size)
+	for i := range row {
+		row[i] = 1 / float64(size)
+	}
+	return NewSeparableKernel(row, row)
+}
+
+// SharpenKernel renvoie un noyau 3 × 3 qui accentue les contours.
+func SharpenKernel() Kernel {
+	return NewKernel([][]float64{
+		{0, -1, 0},
+		{-1, 5, -1},
+		{0, -1, 0},
+	})
+}
+
+// EmbossKernel renvoie un noyau 3 × 3 d'estampage, éclairé depuis le coin supérieur gauche.
+// Il s'utilise avec un décalage Bias de la moitié de la valeur maximale.
+func EmbossKernel() Kernel {
+	return NewKernel([][]float64{
+		{1, 1, 0},
+		{1, 0, -1},
+		{0, -1, -1},
+	})
+}
+
+// ConvolvePlane applique le noyau à un plan width × height et renvoie un nouveau plan. Le noyau est appliqué
+// tel quel, sans être retourné ; hors de l'image, les valeurs suivent edge et valent fill avec EdgeConstant.
+func ConvolvePlane(src []float64, width, height int, k Kernel, edge EdgeMode, fill float64) []float64 {
+	cx, cy := k.Width/2, k.Height/2
+	at := func(plane []float64, x, y int, outside float64) float64 {
+		sx, okX := EdgeIndex(x, width, edge)
+		sy, okY := EdgeIndex(y, height, edge)
+		if !okX || !okY {
+			return outside
+		}
+		return plane[sy*width+sx]
+	}
+
+	dst := make([]float64, width*height)
+	if k.Separable() {
+		// Passe horizontale puis verticale. Hors de l'image, une ligne entière vaut fill,
+		// son résultat horizontal est donc fill multiplié par la somme de la ligne du noyau.
+		rowSum := 0.0
+		for _, v := range k.row {
+			rowSum += v
+		}
+		tmp := make([]float64, width*height)
+		for y := 0; y < height; y++ {
+			for x := 0; x < width; x++ {
+				v := 0.0
+				for i, w := range k.row {
+					v += w * at(src, x+i-cx, y, fill)
+				}
+				tmp[y*width+x] = v
+			}
+		}
+		for y := 0; y < height; y++ {
+			for x := 0; x < width; x++ {
+				v := 0.0
+				for j, w := range k.column {
+					v += w * at(tmp, x, y+j-cy, fill*rowSum)
+				}
+				dst[y*width+x] = v
+			}
+		}
+		return dst
+	}
+
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			v := 0.0
+			for j := 0; j < k.Height; j++ {
+				for i := 0; i < k.Width; i++ {
+					if w := k.Values[j*k.Width+i]; w != 0 {
+						v += w * at(src, x+i-cx, y+j-cy, fill)
+					}
+				}
+			}
+			dst[y*width+x] = v
+		}
+	}
+	return dst
+}
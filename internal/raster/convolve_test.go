@@ -0,0 +1,28 @@
+package raster
+
+import (
+	"math"
+	"testing"
+)
+
+func TestConvolvePlaneSeparable(t *testing.T) {
+	separable := NewKernel([][]float64{{1, 2, 1}, {2, 4, 2}, {1, 2, 1}})
+	if !separable.Separable() || SharpenKernel().Separable() {
+		t.Fatal("Separability detection wrong")
+	}
+	direct := Kernel{Width: 3, Height: 3, Values: separable.Values}
+
+	plane := make([]float64, 7*5)
+	for i := range plane {
+		plane[i] = float64((i * 37) % 256)
+	}
+	for _, edge := range []EdgeMode{EdgeConstant, EdgeReplicate, EdgeReflect, EdgeWrap} {
+		a := ConvolvePlane(plane, 7, 5, separable, edge, 50)
+		b := ConvolvePlane(plane, 7, 5, direct, edge, 50)
+		for i := range a {
+			if math.Abs(a[i]-b[i]) > 1e-9 {
+				t.Fatalf("Edge %d: value %d separable %v, direct %v", edge, i, a[i], b[i])
+			}
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package raster
+
+import "math"
+
+// NonLocalMeansOptions regroupe les paramètres du débruitage par moyennes non locales.
+type NonLocalMeansOptions struct {
+	// PatchRadius est le rayon des voisinages comparés entre eux.
+	PatchRadius int
+	// SearchRadius est le rayon de la zone où sont cherchés les voisinages semblables.
+	SearchRadius int
+	// H règle la force du lissage, dans les unités des valeurs de l'image ; il est de l'ordre
+	// de l'écart type du bruit.
+	H float64
+}
+
+// BilateralPlanes applique le filtre bilatéral à des plans de même taille. La différence de valeur
+// entre deux pixels est la distance euclidienne sur l'ensemble des plans, qui sont donc lissés ensemble.
+// Les lignes sont traitées en parallèle.
+func BilateralPlanes(src [][]float64, width, height int, spatialSigma, rangeSigma float64) [][]float64 {
+	radius := max(1, int(math.Ceil(2*spatialSigma)))
+	size := 2*radius + 1
+	spatial := make([]float64, size*size)
+	for j := -radius; j <= radius; j++ {
+		for i := -radius; i <= radius; i++ {
+			spatial[(j+radius)*size+i+radius] = math.Exp(-float64(i*i+j*j) / (2 * spatialSigma * spatialSigma))
+		}
+	}
+	rangeFactor := -1 / (2 * rangeSigma * rangeSigma)
+
+	dst := make([][]float64, len(src))
+	for c := range dst {
+		dst[c] = make([]float64, width*height)
+	}
+	ParallelRows(height, func(y int) {
+		sums := make([]float64, len(src))
+		for x := 0; x < width; x++ {
+			center := y*width + x
+			for c := range sums {
+				sums[c] = 0
+			}
+			total := 0.0
+			for j := -radius; j <= radius; j++ {
+				sy := min(max(y+j, 0), height-1)
+				for i := -radius; i <= radius; i++ {
+					sx := min(max(x+i, 0), width-1)
+					k := sy*width + sx
+					d2 := 0.0
+					for _, plane := range src {
+						d := plane[k] - plane[center]
+						d2 += d * d
+					}
+					w := spatial[(j+radius)*size+i+radius] * math.Exp(d2*rangeFactor)
+					for c, plane := range src {
+						sums[c] += w * plane[k]
+					}
+					total += w
+				}
+			}
+			for c := range dst {
+				dst[c][center] = sums[c] / total
+			}
+		}
+	})
+	return dst
+}
+
+// NonLocalMeansPlanes applique les moyennes non locales à des plans de même taille. Pour chaque décalage
+// de la zone de recherche, la distance entre voisinages est obtenue d'un coup pour toute l'image
+// par un flou moyenneur de l'écart au carré : le coût ne dépend pas de la taille des voisinages.
+func NonLocalMeansPlanes(src [][]float64, width, height int, options NonLocalMeansOptions) [][]float64 {
+	n := width * height
+	sums := make([][]float64, len(src))
+	for c := range sums {
+		sums[c] = make([]float64, n)
+	}
+	totals := make([]float64, n)
+	diff := make([]float64, n)
+	factor := -1 / (options.H * options.H)
+	r := options.SearchRadius
+
+	for dy := -r; dy <= r; dy++ {
+		for dx := -r; dx <= r; dx++ {
+			shifted := func(x, y int) int {
+				return min(max(y+dy, 0), height-1)*width + min(max(x+dx, 0), width-1)
+			}
+			ParallelRows(height, func(y int) {
+				for x := 0; x < width; x++ {
+					i, k := y*width+x, shifted(x, y)
+					d2 := 0.0
+					for _, plane := range src {
+						d := plane[i] - plane[k]
+						d2 += d * d
+					}
+					diff[i] = d2 / float64(len(src))
+				}
+			})
+			distance := diff
+			if options.PatchRadius > 0 {
+				distance = BoxBlurPlane(diff, width, height, options.PatchRadius)
+			}
+			ParallelRows(height, func(y int) {
+				for x := 0; x < width; x++ {
+					i, k := y*width+x, shifted(x, y)
+					w := math.Exp(distance[i] * factor)
+					for c, plane := range src {
+						sums[c][i] += w * plane[k]
+					}
+					totals[i] += w
+				}
+			})
+		}
+	}
+
+	for c := range sums {
+		for i := range sums[c] {
+			sums[c][i] /= totals[i]
+		}
+	}
+	return sums
+}
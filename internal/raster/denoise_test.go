@@ -0,0 +1,33 @@
+package raster
+
+import (
+	"math"
+	"testing"
+)
+
+func TestDenoisePlanesFlat(t *testing.T) {
+	flat := make([]float64, 6*4)
+	for i := range flat {
+		flat[i] = 80
+	}
+	bilateral := BilateralPlanes([][]float64{flat, flat}, 6, 4, 1.5, 20)
+	means := NonLocalMeansPlanes([][]float64{flat}, 6, 4, NonLocalMeansOptions{PatchRadius: 1, SearchRadius: 2, H: 10})
+	for i := range flat {
+		if math.Abs(bilateral[0][i]-80) > 1e-9 || math.Abs(bilateral[1][i]-80) > 1e-9 || math.Abs(means[0][i]-80) > 1e-9 {
+			t.Fatalf("Flat planes changed at %d: %v %v %v", i, bilateral[0][i], bilateral[1][i], means[0][i])
+		}
+	}
+}
+
+func TestBilateralPlanesKeepsEdges(t *testing.T) {
+	step := make([]float64, 8*3)
+	for i := range step {
+		if i%8 >= 4 {
+			step[i] = 200
+		}
+	}
+	result := BilateralPlanes([][]float64{step}, 8, 3, 2, 10)[0]
+	if result[3] > 1 || result[4] < 199 {
+		t.Errorf("Edge should be kept: %v %v", result[3], result[4])
+	}
+}
@@ -0,0 +1,82 @@
+package raster
+
+// EdgeMode définit la valeur des pixels situés hors de l'image.
+type EdgeMode int
+
+const (
+	// EdgeConstant utilise une valeur fixe.
+	EdgeConstant EdgeMode = iota
+	// EdgeReplicate prolonge le pixel du bord le plus proche.
+	EdgeReplicate
+	// EdgeReflect reflète l'image comme dans un miroir posé sur le bord, pixel du bord compris (dcba|abcd|dcba).
+	EdgeReflect
+	// EdgeWrap répète l'image comme une mosaïque.
+	EdgeWrap
+)
+
+// EdgeIndex ramène l'indice i dans [0, size) selon le mode. Elle renvoie false quand i est hors de l'image
+// avec EdgeConstant, et pour tout i quand size est nul : il n'y a alors aucun pixel à prolonger.
+func EdgeIndex(i, size int, mode EdgeMode) (int, bool) {
+	if i >= 0 && i < size {
+		return i, true
+	}
+	if size <= 0 {
+		return 0, false
+	}
+	switch mode {
+	case EdgeReplicate:
+		return min(max(i, 0), size-1), true
+	case EdgeReflect:
+		period := 2 * size
+		m := ((i % period) + period) % period
+		if m >= size {
+			m = period - 1 - m
+		}
+		return m, true
+	case EdgeWrap:
+		return ((i % size) + size) % size, true
+	}
+	return 0, false
+}
+
+// ContentBounds renvoie le plus petit rectangle (x, y, width, height) contenant tous les pixels qui ne
+// sont pas de la bordure, et false si tous les pixels en font partie.
+func ContentBounds(width, height int, isBorder func(x, y int) bool) (x, y, w, h int, ok bool) {
+	rowIsBorder := func(y int) bool {
+		for x := 0; x < width; x++ {
+			if !isBorder(x, y) {
+				return false
+			}
+		}
+		return true
+	}
+	top := 0
+	for top < height && rowIsBorder(top) {
+		top++
+	}
+	if top == height {
+		return 0, 0, 0, 0, false
+	}
+	bottom := height - 1
+	for rowIsBorder(bottom) {
+		bottom--
+	}
+
+	columnIsBorder := func(x int) bool {
+		for y := top; y <= bottom; y++ {
+			if !isBorder(x, y) {
+				return false
+			}
+		}
+		return true
+	}
+	left := 0
+	for columnIsBorder(left) {
+		left++
+	}
+	right := width - 1
+	for columnIsBorder(right) {
+		right--
+	}
+	return left, top, right - left + 1, bottom - top + 1, true
+}
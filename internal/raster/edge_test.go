@@ -0,0 +1,45 @@
+package raster
+
+import "testing"
+
+func TestEdgeIndex(t *testing.T) {
+	tests := []struct {
+		i, size int
+		mode    EdgeMode
+		want    int
+		ok      bool
+	}{
+		{2, 4, EdgeConstant, 2, true},
+		{-1, 4, EdgeConstant, 0, false},
+		{-2, 4, EdgeReplicate, 0, true},
+		{5, 4, EdgeReplicate, 3, true},
+		{-1, 4, EdgeReflect, 0, true},
+		{4, 4, EdgeReflect, 3, true},
+		{-1, 4, EdgeWrap, 3, true},
+		{9, 4, EdgeWrap, 1, true},
+	}
+	for _, test := range tests {
+		got, ok := EdgeIndex(test.i, test.size, test.mode)
+		if got != test.want || ok != test.ok {
+			t.Errorf("EdgeIndex(%d, %d, %d): wanted %d %v got %d %v", test.i, test.size, test.mode, test.want, test.ok, got, ok)
+		}
+	}
+	// Une image vide n'a aucun pixel à prolonger
+	for _, mode := range []EdgeMode{EdgeConstant, EdgeReplicate, EdgeReflect, EdgeWrap} {
+		if _, ok := EdgeIndex(-1, 0, mode); ok {
+			t.Errorf("Mode %d on an empty size should report no pixel", mode)
+		}
+	}
+}
+
+func TestContentBounds(t *testing.T) {
+	isBorder := func(x, y int) bool {
+		return !(x >= 2 && x < 5 && y >= 1 && y < 3)
+	}
+	if x, y, w, h, ok := ContentBounds(8, 6, isBorder); !ok || x != 2 || y != 1 || w != 3 || h != 2 {
+		t.Errorf("ContentBounds wrong: %d %d %d %d %v", x, y, w, h, ok)
+	}
+	if _, _, _, _, ok := ContentBounds(4, 4, func(x, y int) bool { return true }); ok {
+		t.Error("Uniform image should have no content")
+	}
+}
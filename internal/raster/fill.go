@@ -0,0 +1,66 @@
+package raster
+
+// fillSpan est le début d'un segment horizontal de pixels à examiner pendant le remplissage.
+type fillSpan struct {
+	x, y int
+}
+
+// ScanlineFill parcourt la zone connexe contenant (x, y) en remplissant des segments horizontaux.
+// Une pile explicite remplace la récursion pour accepter de très grandes zones.
+// inside indique si un pixel appartient à la zone, fill est appelé une fois par pixel de la zone.
+// Avec diagonal, les pixels voisins en diagonale sont aussi reliés (8-connexité).
+// Un point de départ hors de l'image, ou hors de la zone, ne remplit rien.
+func ScanlineFill(width, height, x, y int, diagonal bool, inside func(x, y int) bool, fill func(x, y int)) {
+	if x < 0 || x >= width || y < 0 || y >= height || !inside(x, y) {
+		return
+	}
+
+	visited := make([]bool, width*height)
+	stack := []fillSpan{{x, y}}
+
+	// En 8-connexité, les segments voisins peuvent commencer en diagonale
+	reach := 0
+	if diagonal {
+		reach = 1
+	}
+
+	for len(stack) > 0 {
+		s := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if visited[s.y*width+s.x] || !inside(s.x, s.y) {
+			continue
+		}
+
+		// Étendre le segment vers la gauche et vers la droite
+		left := s.x
+		for left > 0 && !visited[s.y*width+left-1] && inside(left-1, s.y) {
+			left--
+		}
+		right := s.x
+		for right < width-1 && !visited[s.y*width+right+1] && inside(right+1, s.y) {
+			right++
+		}
+		for x := left; x <= right; x++ {
+			visited[s.y*width+x] = true
+			fill(x, s.y)
+		}
+
+		// Empiler le début de chaque segment candidat sur les lignes voisines
+		for _, ny := range [2]int{s.y - 1, s.y + 1} {
+			if ny < 0 || ny >= height {
+				continue
+			}
+			inRun := false
+			for x := max(left-reach, 0); x <= min(right+reach, width-1); x++ {
+				if !visited[ny*width+x] && inside(x, ny) {
+					if !inRun {
+						stack = append(stack, fillSpan{x, ny})
+						inRun = true
+					}
+				} else {
+					inRun = false
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,27 @@
+package raster
+
+import "testing"
+
+func TestScanlineFill(t *testing.T) {
+	// Deux carrés 3x3 qui ne se touchent que par un coin
+	inside := func(x, y int) bool {
+		return (x < 3 && y < 3) || (x >= 3 && x < 6 && y >= 3 && y < 6)
+	}
+	count := func(x, y int, diagonal bool) int {
+		n := 0
+		ScanlineFill(6, 6, x, y, diagonal, inside, func(x, y int) { n++ })
+		return n
+	}
+	if got := count(0, 0, false); got != 9 {
+		t.Errorf("4-connectivity: wanted 9 pixels got %d", got)
+	}
+	if got := count(0, 0, true); got != 18 {
+		t.Errorf("8-connectivity: wanted 18 pixels got %d", got)
+	}
+	if got := count(-1, 0, true); got != 0 {
+		t.Errorf("Out of bounds seed: wanted 0 pixels got %d", got)
+	}
+	if got := count(5, 0, true); got != 0 {
+		t.Errorf("Seed outside region: wanted 0 pixels got %d", got)
+	}
+}
@@ -0,0 +1,57 @@
+package raster
+
+// Line appelle plot pour chaque pixel du segment allant de (x0, y0) à (x1, y1), extrémités comprises,
+// avec l'algorithme de Bresenham.
+func Line(x0, y0, x1, y1 int, plot func(x, y int)) {
+	dx := x1 - x0
+	if dx < 0 {
+		dx = -dx
+	}
+	dy := y1 - y0
+	if dy > 0 {
+		dy = -dy
+	}
+	sx, sy := 1, 1
+	if x0 > x1 {
+		sx = -1
+	}
+	if y0 > y1 {
+		sy = -1
+	}
+	err := dx + dy
+
+	x, y := x0, y0
+	for {
+		plot(x, y)
+		if x == x1 && y == y1 {
+			break
+		}
+		e2 := 2 * err
+		if e2 >= dy {
+			err += dy
+			x += sx
+		}
+		if e2 <= dx {
+			err += dx
+			y += sy
+		}
+	}
+}
+
+// CircleOctant appelle octant pour chaque point (x, y) du premier octant (x >= y >= 0) d'un cercle
+// de rayon radius centré sur l'origine, avec l'algorithme du point milieu.
+// Les sept autres octants s'obtiennent par symétrie.
+func CircleOctant(radius int, octant func(x, y int)) {
+	x, y := radius, 0
+	err := 1 - radius
+	for x >= y {
+		octant(x, y)
+		y++
+		if err < 0 {
+			err += 2*y + 1
+		} else {
+			x--
+			err += 2*(y-x) + 1
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package raster
+
+import "testing"
+
+func TestLine(t *testing.T) {
+	type point struct{ x, y int }
+	var got []point
+	Line(3, 2, 0, 0, func(x, y int) { got = append(got, point{x, y}) })
+	want := []point{{3, 2}, {2, 1}, {1, 1}, {0, 0}}
+	if len(got) != len(want) {
+		t.Fatalf("Line wrong: %v", got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("Line wrong: %v", got)
+		}
+	}
+
+	n := 0
+	Line(4, 4, 4, 4, func(x, y int) { n++ })
+	if n != 1 {
+		t.Errorf("Single point line: wanted 1 pixel got %d", n)
+	}
+}
+
+func TestCircleOctant(t *testing.T) {
+	for _, radius := range []int{1, 5, 12} {
+		prevX, prevY := radius+1, -1
+		CircleOctant(radius, func(x, y int) {
+			if x < y || y != prevY+1 || x > prevX || prevX-x > 1 {
+				t.Errorf("Radius %d: point (%d, %d) after (%d, %d)", radius, x, y, prevX, prevY)
+			}
+			// Le point reste à moins d'un demi-pixel du cercle
+			if d := x*x + y*y - radius*radius; d > radius || d < -radius {
+				t.Errorf("Radius %d: point (%d, %d) too far from the circle", radius, x, y)
+			}
+			prevX, prevY = x, y
+		})
+		if prevY < 0 {
+			t.Errorf("Radius %d: no point", radius)
+		}
+	}
+}
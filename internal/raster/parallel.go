@@ -0,0 +1,24 @@
+package raster
+
+import (
+	"runtime"
+	"sync"
+)
+
+// ParallelRows appelle row pour chaque ligne de 0 à height-1, en répartissant les lignes entre les processeurs.
+// row doit pouvoir être appelé en même temps pour des lignes différentes.
+func ParallelRows(height int, row func(y int)) {
+	workers := min(runtime.NumCPU(), height)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			// Les lignes sont entrelacées pour équilibrer la charge entre zones faciles et difficiles
+			for y := w; y < height; y += workers {
+				row(y)
+			}
+		}(w)
+	}
+	wg.Wait()
+}
@@ -0,0 +1,17 @@
+package raster
+
+import "testing"
+
+func TestParallelRows(t *testing.T) {
+	for _, height := range []int{0, 1, 7, 1000} {
+		seen := make([]int, height)
+		ParallelRows(height, func(y int) {
+			seen[y]++
+		})
+		for y, n := range seen {
+			if n != 1 {
+				t.Fatalf("Height %d: row %d visited %d times", height, y, n)
+			}
+		}
+	}
+}
@@ -0,0 +1,429 @@
+// Package raster regroupe la géométrie commune aux trois formats : chemins vectoriels, aplatissement
+// des courbes, construction des traits et remplissage de polygones par balayage. Les paquets d'images
+// n'ont plus qu'à écrire les intervalles de pixels produits, chacun avec son type de pixel.
+package raster
+
+import (
+	"math"
+	"sort"
+)
+
+// PointF représente un point en coordonnées flottantes.
+type PointF struct {
+	X, Y float64
+}
+
+// FillRule définit la règle utilisée pour décider si un point est à l'intérieur d'un chemin.
+type FillRule int
+
+const (
+	// FillNonZero remplit les zones dont l'indice d'enroulement est non nul.
+	FillNonZero FillRule = iota
+	// FillEvenOdd remplit les zones traversées un nombre impair de fois.
+	FillEvenOdd
+)
+
+// DefaultTolerance est l'écart maximal, en pixels, entre une courbe et sa version aplatie.
+const DefaultTolerance = 0.25
+
+// Path représente un chemin vectoriel composé d'un ou plusieurs sous-chemins.
+type Path struct {
+	subpaths []subpath
+	start    PointF
+	current  PointF
+	// Tolerance est l'écart maximal (en pixels) toléré lors de l'aplatissement des courbes.
+	Tolerance float64
+	// FillRule est la règle de remplissage utilisée pour remplir le chemin.
+	FillRule FillRule
+}
+
+// subpath est une ligne brisée déjà aplatie.
+type subpath struct {
+	points []PointF
+	closed bool
+}
+
+// NewPath crée un chemin vide.
+func NewPath() *Path {
+	return &Path{Tolerance: DefaultTolerance}
+}
+
+// last renvoie le sous-chemin en cours, en le créant au besoin.
+func (p *Path) last() *subpath {
+	if len(p.subpaths) == 0 || p.subpaths[len(p.subpaths)-1].closed {
+		p.subpaths = append(p.subpaths, subpath{points: []PointF{p.current}})
+		p.start = p.current
+	}
+	return &p.subpaths[len(p.subpaths)-1]
+}
+
+// EffectiveTolerance renvoie la tolérance d'aplatissement effective.
+func (p *Path) EffectiveTolerance() float64 {
+	if p.Tolerance <= 0 {
+		return DefaultTolerance
+	}
+	return p.Tolerance
+}
+
+// CurrentPoint renvoie la position courante du chemin.
+func (p *Path) CurrentPoint() PointF {
+	return p.current
+}
+
+// MoveTo commence un nouveau sous-chemin au point (x, y).
+func (p *Path) MoveTo(x, y float64) {
+	p.current = PointF{x, y}
+	p.start = p.current
+	p.subpaths = append(p.subpaths, subpath{points: []PointF{p.current}})
+}
+
+// LineTo ajoute un segment de droite jusqu'au point (x, y).
+func (p *Path) LineTo(x, y float64) {
+	sp := p.last()
+	p.current = PointF{x, y}
+	sp.points = append(sp.points, p.current)
+}
+
+// QuadTo ajoute une courbe de Bézier quadratique de point de contrôle (cx, cy) jusqu'au point (x, y).
+func (p *Path) QuadTo(cx, cy, x, y float64) {
+	// Une quadratique est une cubique dont les points de contrôle sont aux deux tiers
+	p0 := p.current
+	c1x := p0.X + 2.0/3.0*(cx-p0.X)
+	c1y := p0.Y + 2.0/3.0*(cy-p0.Y)
+	c2x := x + 2.0/3.0*(cx-x)
+	c2y := y + 2.0/3.0*(cy-y)
+	p.CubicTo(c1x, c1y, c2x, c2y, x, y)
+}
+
+// CubicTo ajoute une courbe de Bézier cubique de points de contrôle (c1x, c1y) et (c2x, c2y) jusqu'au point (x, y).
+func (p *Path) CubicTo(c1x, c1y, c2x, c2y, x, y float64) {
+	sp := p.last()
+	p0 := p.current
+	p3 := PointF{x, y}
+	sp.points = flattenCubic(sp.points, p0, PointF{c1x, c1y}, PointF{c2x, c2y}, p3, p.EffectiveTolerance(), 0)
+	p.current = p3
+}
+
+// flattenCubic subdivise récursivement une cubique jusqu'à ce qu'elle soit assez plate.
+func flattenCubic(points []PointF, p0, p1, p2, p3 PointF, tolerance float64, depth int) []PointF {
+	if depth >= 16 || cubicFlatness(p0, p1, p2, p3) <= tolerance {
+		return append(points, p3)
+	}
+
+	// Subdivision de De Casteljau en t = 0.5
+	p01 := MidPoint(p0, p1)
+	p12 := MidPoint(p1, p2)
+	p23 := MidPoint(p2, p3)
+	p012 := MidPoint(p01, p12)
+	p123 := MidPoint(p12, p23)
+	mid := MidPoint(p012, p123)
+
+	points = flattenCubic(points, p0, p01, p012, mid, tolerance, depth+1)
+	return flattenCubic(points, mid, p123, p23, p3, tolerance, depth+1)
+}
+
+// cubicFlatness renvoie la distance maximale entre les points de contrôle et la corde.
+func cubicFlatness(p0, p1, p2, p3 PointF) float64 {
+	return math.Max(distanceToLine(p1, p0, p3), distanceToLine(p2, p0, p3))
+}
+
+// distanceToLine renvoie la distance du point p à la droite (a, b).
+func distanceToLine(p, a, b PointF) float64 {
+	dx, dy := b.X-a.X, b.Y-a.Y
+	length := math.Hypot(dx, dy)
+	if length == 0 {
+		return math.Hypot(p.X-a.X, p.Y-a.Y)
+	}
+	return math.Abs((p.X-a.X)*dy-(p.Y-a.Y)*dx) / length
+}
+
+// MidPoint renvoie le milieu du segment [a, b].
+func MidPoint(a, b PointF) PointF {
+	return PointF{(a.X + b.X) / 2, (a.Y + b.Y) / 2}
+}
+
+// ArcTo ajoute un arc d'ellipse jusqu'au point (x, y), avec la même paramétrisation que la commande A du SVG :
+// rayons rx et ry, rotation de l'axe x en degrés, choix du grand arc et sens de parcours.
+func (p *Path) ArcTo(rx, ry, rotation float64, largeArc, sweep bool, x, y float64) {
+	p0 := p.current
+	if p0.X == x && p0.Y == y {
+		return
+	}
+	rx, ry = math.Abs(rx), math.Abs(ry)
+	if rx == 0 || ry == 0 {
+		p.LineTo(x, y)
+		return
+	}
+
+	cx, cy, rx, ry, theta1, delta := ArcCenter(p0, rx, ry, rotation, largeArc, sweep, PointF{x, y})
+	phi := rotation * math.Pi / 180
+	p.ellipticalArc(cx, cy, rx, ry, phi, theta1, delta)
+	// Éviter l'accumulation d'erreurs d'arrondi sur le point final
+	sp := &p.subpaths[len(p.subpaths)-1]
+	sp.points[len(sp.points)-1] = PointF{x, y}
+	p.current = PointF{x, y}
+}
+
+// ArcCenter convertit un arc défini par ses extrémités (commande A du SVG) en arc défini par son centre.
+// Elle renvoie le centre, les rayons éventuellement agrandis, l'angle de départ et l'amplitude de l'arc.
+func ArcCenter(p0 PointF, rx, ry, rotation float64, largeArc, sweep bool, p1 PointF) (cx, cy, rxOut, ryOut, theta, delta float64) {
+	phi := rotation * math.Pi / 180
+	cosPhi, sinPhi := math.Cos(phi), math.Sin(phi)
+	dx2, dy2 := (p0.X-p1.X)/2, (p0.Y-p1.Y)/2
+	x1p := cosPhi*dx2 + sinPhi*dy2
+	y1p := -sinPhi*dx2 + cosPhi*dy2
+
+	// Agrandir les rayons s'ils sont trop petits pour relier les deux points
+	lambda := (x1p*x1p)/(rx*rx) + (y1p*y1p)/(ry*ry)
+	if lambda > 1 {
+		s := math.Sqrt(lambda)
+		rx *= s
+		ry *= s
+	}
+
+	num := rx*rx*ry*ry - rx*rx*y1p*y1p - ry*ry*x1p*x1p
+	den := rx*rx*y1p*y1p + ry*ry*x1p*x1p
+	coef := 0.0
+	if den != 0 && num > 0 {
+		coef = math.Sqrt(num / den)
+	}
+	if largeArc == sweep {
+		coef = -coef
+	}
+	cxp := coef * rx * y1p / ry
+	cyp := -coef * ry * x1p / rx
+	cx = cosPhi*cxp - sinPhi*cyp + (p0.X+p1.X)/2
+	cy = sinPhi*cxp + cosPhi*cyp + (p0.Y+p1.Y)/2
+
+	theta = math.Atan2((y1p-cyp)/ry, (x1p-cxp)/rx)
+	theta2 := math.Atan2((-y1p-cyp)/ry, (-x1p-cxp)/rx)
+	delta = theta2 - theta
+	if sweep && delta < 0 {
+		delta += 2 * math.Pi
+	} else if !sweep && delta > 0 {
+		delta -= 2 * math.Pi
+	}
+	return cx, cy, rx, ry, theta, delta
+}
+
+// Arc ajoute un arc de cercle de centre (cx, cy) et de rayon r, de l'angle start à l'angle end (en radians).
+// Si le chemin contient déjà un point courant, un segment le relie au début de l'arc.
+func (p *Path) Arc(cx, cy, r, start, end float64) {
+	first := PointF{cx + r*math.Cos(start), cy + r*math.Sin(start)}
+	if len(p.subpaths) == 0 {
+		p.MoveTo(first.X, first.Y)
+	} else {
+		p.LineTo(first.X, first.Y)
+	}
+	p.ellipticalArc(cx, cy, r, r, 0, start, end-start)
+}
+
+// ellipticalArc ajoute au sous-chemin courant les points d'un arc d'ellipse.
+func (p *Path) ellipticalArc(cx, cy, rx, ry, phi, theta, delta float64) {
+	sp := p.last()
+
+	// Nombre de segments nécessaire pour respecter la tolérance
+	r := math.Max(rx, ry)
+	step := math.Pi / 2
+	if r > p.EffectiveTolerance() {
+		step = 2 * math.Acos(1-p.EffectiveTolerance()/r)
+	}
+	n := int(math.Ceil(math.Abs(delta) / step))
+	if n < 1 {
+		n = 1
+	}
+
+	cosPhi, sinPhi := math.Cos(phi), math.Sin(phi)
+	for i := 1; i <= n; i++ {
+		t := theta + delta*float64(i)/float64(n)
+		ex, ey := rx*math.Cos(t), ry*math.Sin(t)
+		point := PointF{cx + cosPhi*ex - sinPhi*ey, cy + sinPhi*ex + cosPhi*ey}
+		sp.points = append(sp.points, point)
+	}
+	p.current = sp.points[len(sp.points)-1]
+}
+
+// Close ferme le sous-chemin courant en le reliant à son point de départ.
+func (p *Path) Close() {
+	if len(p.subpaths) == 0 {
+		return
+	}
+	sp := &p.subpaths[len(p.subpaths)-1]
+	if sp.closed {
+		return
+	}
+	sp.closed = true
+	p.current = p.start
+}
+
+// Polylines renvoie les sous-chemins aplatis sous forme de lignes brisées.
+// Les sous-chemins fermés se terminent par leur point de départ.
+func (p *Path) Polylines() [][]PointF {
+	polylines := make([][]PointF, 0, len(p.subpaths))
+	for _, sp := range p.subpaths {
+		points := append([]PointF(nil), sp.points...)
+		if sp.closed && len(points) > 1 && points[len(points)-1] != points[0] {
+			points = append(points, points[0])
+		}
+		polylines = append(polylines, points)
+	}
+	return polylines
+}
+
+// Map renvoie une copie du chemin dont tous les points sont transformés par f.
+func (p *Path) Map(f func(PointF) PointF) *Path {
+	result := &Path{
+		subpaths:  make([]subpath, len(p.subpaths)),
+		start:     f(p.start),
+		current:   f(p.current),
+		Tolerance: p.Tolerance,
+		FillRule:  p.FillRule,
+	}
+	for i, sp := range p.subpaths {
+		points := make([]PointF, len(sp.points))
+		for j, point := range sp.points {
+			points[j] = f(point)
+		}
+		result.subpaths[i] = subpath{points: points, closed: sp.closed}
+	}
+	return result
+}
+
+// StrokePolygons construit les polygones couvrant le trait d'un chemin.
+// Tous les polygones sont orientés dans le même sens afin que leur union soit obtenue avec la règle non nulle.
+func StrokePolygons(path *Path, width, tolerance float64) [][]PointF {
+	half := width / 2
+	var polygons [][]PointF
+	for _, polyline := range path.Polylines() {
+		for i, point := range polyline {
+			polygons = append(polygons, circlePolygon(point, half, tolerance))
+			if i+1 == len(polyline) {
+				break
+			}
+			next := polyline[i+1]
+			dx, dy := next.X-point.X, next.Y-point.Y
+			length := math.Hypot(dx, dy)
+			if length == 0 {
+				continue
+			}
+			nx, ny := -dy/length*half, dx/length*half
+			quad := []PointF{
+				{point.X + nx, point.Y + ny},
+				{next.X + nx, next.Y + ny},
+				{next.X - nx, next.Y - ny},
+				{point.X - nx, point.Y - ny},
+			}
+			if signedArea(quad) < 0 {
+				quad[0], quad[1], quad[2], quad[3] = quad[3], quad[2], quad[1], quad[0]
+			}
+			polygons = append(polygons, quad)
+		}
+	}
+	return polygons
+}
+
+// circlePolygon approxime un cercle par un polygone d'orientation positive.
+func circlePolygon(center PointF, r, tolerance float64) []PointF {
+	n := 8
+	if r > tolerance {
+		n = int(math.Ceil(2 * math.Pi / (2 * math.Acos(1-tolerance/r))))
+		if n < 8 {
+			n = 8
+		}
+	}
+	polygon := make([]PointF, n)
+	for i := range polygon {
+		a := 2 * math.Pi * float64(i) / float64(n)
+		polygon[i] = PointF{center.X + r*math.Cos(a), center.Y + r*math.Sin(a)}
+	}
+	return polygon
+}
+
+// signedArea renvoie l'aire signée d'un polygone.
+func signedArea(polygon []PointF) float64 {
+	area := 0.0
+	for i := range polygon {
+		a := polygon[i]
+		b := polygon[(i+1)%len(polygon)]
+		area += a.X*b.Y - b.X*a.Y
+	}
+	return area / 2
+}
+
+// edgeCrossing est l'intersection d'une arête avec une ligne de balayage.
+type edgeCrossing struct {
+	x       float64
+	winding int
+}
+
+// FillPolygons remplit un ensemble de polygones par balayage de lignes.
+// Un pixel est rempli si son centre est à l'intérieur ; span est appelé pour chaque intervalle [x0, x1) de la ligne y.
+func FillPolygons(polygons [][]PointF, rule FillRule, width, height int, span func(y, x0, x1 int)) {
+	minY, maxY := math.Inf(1), math.Inf(-1)
+	for _, polygon := range polygons {
+		for _, point := range polygon {
+			minY = math.Min(minY, point.Y)
+			maxY = math.Max(maxY, point.Y)
+		}
+	}
+	if math.IsInf(minY, 0) {
+		return
+	}
+
+	yStart := int(math.Max(0, math.Ceil(minY-0.5)))
+	yEnd := int(math.Min(float64(height-1), math.Floor(maxY-0.5)))
+
+	var crossings []edgeCrossing
+	for y := yStart; y <= yEnd; y++ {
+		scanY := float64(y) + 0.5
+		crossings = crossings[:0]
+
+		// Trouver les intersections des arêtes avec la ligne de balayage
+		for _, polygon := range polygons {
+			n := len(polygon)
+			for i := 0; i < n; i++ {
+				a := polygon[i]
+				b := polygon[(i+1)%n]
+				if a.Y == b.Y {
+					continue
+				}
+				winding := 1
+				if a.Y > b.Y {
+					a, b = b, a
+					winding = -1
+				}
+				if scanY < a.Y || scanY >= b.Y {
+					continue
+				}
+				x := a.X + (scanY-a.Y)*(b.X-a.X)/(b.Y-a.Y)
+				crossings = append(crossings, edgeCrossing{x, winding})
+			}
+		}
+		sort.Slice(crossings, func(i, j int) bool {
+			return crossings[i].x < crossings[j].x
+		})
+
+		// Remplir les intervalles intérieurs
+		count := 0
+		for i := 0; i+1 < len(crossings); i++ {
+			if rule == FillEvenOdd {
+				count ^= 1
+			} else {
+				count += crossings[i].winding
+			}
+			if count == 0 {
+				continue
+			}
+			x0 := int(math.Max(0, math.Ceil(crossings[i].x-0.5)))
+			x1 := int(math.Min(float64(width), math.Ceil(crossings[i+1].x-0.5)))
+			if x0 < x1 {
+				span(y, x0, x1)
+			}
+		}
+	}
+}
+
+// Round arrondit un flottant à l'entier le plus proche.
+func Round(v float64) int {
+	return int(math.Floor(v + 0.5))
+}
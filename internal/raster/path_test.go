@@ -0,0 +1,46 @@
+package raster
+
+import "testing"
+
+func countSpans(polygons [][]PointF, rule FillRule, width, height int) int {
+	count := 0
+	FillPolygons(polygons, rule, width, height, func(y, x0, x1 int) {
+		count += x1 - x0
+	})
+	return count
+}
+
+func TestFillRules(t *testing.T) {
+	outer := []PointF{{X: 0, Y: 0}, {X: 10, Y: 0}, {X: 10, Y: 10}, {X: 0, Y: 10}}
+	inner := []PointF{{X: 2, Y: 2}, {X: 8, Y: 2}, {X: 8, Y: 8}, {X: 2, Y: 8}}
+	if got := countSpans([][]PointF{outer, inner}, FillNonZero, 20, 20); got != 100 {
+		t.Errorf("Nonzero: wanted 100 pixels got %d", got)
+	}
+	if got := countSpans([][]PointF{outer, inner}, FillEvenOdd, 20, 20); got != 64 {
+		t.Errorf("Even-odd: wanted 64 pixels got %d", got)
+	}
+	if got := countSpans([][]PointF{outer}, FillNonZero, 5, 5); got != 25 {
+		t.Errorf("Clipping: wanted 25 pixels got %d", got)
+	}
+}
+
+func TestPathMapAndStroke(t *testing.T) {
+	p := NewPath()
+	p.MoveTo(1, 1)
+	p.LineTo(4, 1)
+	p.Close()
+	moved := p.Map(func(q PointF) PointF { return PointF{X: q.X + 10, Y: q.Y * 2} })
+	if polylines := moved.Polylines(); polylines[0][1] != (PointF{X: 14, Y: 2}) || moved.CurrentPoint() != (PointF{X: 11, Y: 2}) {
+		t.Errorf("Map wrong: %v", polylines)
+	}
+	if p.Polylines()[0][1] != (PointF{X: 4, Y: 1}) {
+		t.Error("Map should not change the original path")
+	}
+
+	line := NewPath()
+	line.MoveTo(2, 5)
+	line.LineTo(12, 5)
+	if got := countSpans(StrokePolygons(line, 2, line.EffectiveTolerance()), FillNonZero, 20, 20); got < 20 || got > 26 {
+		t.Errorf("Stroke of width 2 covers %d pixels", got)
+	}
+}
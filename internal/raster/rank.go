@@ -0,0 +1,56 @@
+package raster
+
+// RankPlane applique le filtre de rang à un plan width × height selon l'algorithme de Perreault et Hébert :
+// un histogramme par colonne couvre les 2 × radius + 1 lignes de la fenêtre, et l'histogramme de la fenêtre
+// glisse le long de la ligne en ajoutant une colonne et en retirant une autre. Les bords sont prolongés.
+func RankPlane(src []uint8, width, height, radius int, percentile float64) []uint8 {
+	dst := make([]uint8, len(src))
+	if width == 0 || height == 0 {
+		return dst
+	}
+	size := 2*radius + 1
+	rank := int(percentile/100*float64(size*size-1) + 0.5)
+	clampX := func(x int) int { return min(max(x, 0), width-1) }
+	clampY := func(y int) int { return min(max(y, 0), height-1) }
+
+	columns := make([][256]int32, width)
+	for x := range columns {
+		for y := -radius; y <= radius; y++ {
+			columns[x][src[clampY(y)*width+x]]++
+		}
+	}
+
+	for y := 0; y < height; y++ {
+		if y > 0 {
+			// Glissement vertical des histogrammes de colonne
+			out, in := clampY(y-radius-1)*width, clampY(y+radius)*width
+			for x := range columns {
+				columns[x][src[out+x]]--
+				columns[x][src[in+x]]++
+			}
+		}
+
+		var window [256]int32
+		for x := -radius; x <= radius; x++ {
+			column := &columns[clampX(x)]
+			for v := range window {
+				window[v] += column[v]
+			}
+		}
+		for x := 0; x < width; x++ {
+			count := int32(0)
+			for v := range window {
+				count += window[v]
+				if count > int32(rank) {
+					dst[y*width+x] = uint8(v)
+					break
+				}
+			}
+			in, out := &columns[clampX(x+radius+1)], &columns[clampX(x-radius)]
+			for v := range window {
+				window[v] += in[v] - out[v]
+			}
+		}
+	}
+	return dst
+}
@@ -0,0 +1,27 @@
+package raster
+
+import "testing"
+
+func TestRankPlane(t *testing.T) {
+	// Un pixel isolé disparaît avec la médiane et s'étend avec le maximum
+	plane := make([]uint8, 5*5)
+	plane[12] = 200
+	median := RankPlane(plane, 5, 5, 1, 50)
+	maximum := RankPlane(plane, 5, 5, 1, 100)
+	for i := range plane {
+		if median[i] != 0 {
+			t.Errorf("Median at %d: wanted 0 got %d", i, median[i])
+		}
+		x, y := i%5, i/5
+		want := uint8(0)
+		if x >= 1 && x <= 3 && y >= 1 && y <= 3 {
+			want = 200
+		}
+		if maximum[i] != want {
+			t.Errorf("Maximum at (%d, %d): wanted %d got %d", x, y, want, maximum[i])
+		}
+	}
+	if len(RankPlane(nil, 0, 0, 1, 50)) != 0 {
+		t.Error("Empty plane should stay empty")
+	}
+}
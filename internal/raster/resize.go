@@ -0,0 +1,177 @@
+package raster
+
+import "math"
+
+// ResizeFilter est le filtre de reconstruction utilisé pour rééchantillonner une image.
+type ResizeFilter int
+
+const (
+	// FilterBox fait la moyenne des pixels couverts ; en réduction, c'est une moyenne par surface.
+	FilterBox ResizeFilter = iota
+	// FilterBilinear est le filtre triangle, qui interpole linéairement entre deux pixels voisins.
+	FilterBilinear
+	// FilterBicubic est la spline cubique de Catmull-Rom (B = 0, C = 0.5).
+	FilterBicubic
+	// FilterMitchell est le filtre cubique de Mitchell-Netravali (B = C = 1/3).
+	FilterMitchell
+	// FilterLanczos est le filtre de Lanczos à trois lobes.
+	FilterLanczos
+)
+
+// Kernel renvoie la fonction de pondération du filtre et son rayon.
+func (f ResizeFilter) Kernel() (func(x float64) float64, float64) {
+	switch f {
+	case FilterBilinear:
+		return func(x float64) float64 {
+			return math.Max(0, 1-math.Abs(x))
+		}, 1
+	case FilterBicubic:
+		return func(x float64) float64 { return cubicBC(x, 0, 0.5) }, 2
+	case FilterMitchell:
+		return func(x float64) float64 { return cubicBC(x, 1.0/3, 1.0/3) }, 2
+	case FilterLanczos:
+		return func(x float64) float64 {
+			x = math.Abs(x)
+			if x >= 3 {
+				return 0
+			}
+			return sinc(x) * sinc(x/3)
+		}, 3
+	}
+	return func(x float64) float64 {
+		if x >= -0.5 && x < 0.5 {
+			return 1
+		}
+		return 0
+	}, 0.5
+}
+
+// cubicBC est la famille de filtres cubiques de Mitchell-Netravali.
+func cubicBC(x, b, c float64) float64 {
+	x = math.Abs(x)
+	switch {
+	case x < 1:
+		return ((12-9*b-6*c)*x*x*x + (-18+12*b+6*c)*x*x + (6 - 2*b)) / 6
+	case x < 2:
+		return ((-b-6*c)*x*x*x + (6*b+30*c)*x*x + (-12*b-48*c)*x + (8*b + 24*c)) / 6
+	}
+	return 0
+}
+
+func sinc(x float64) float64 {
+	if x == 0 {
+		return 1
+	}
+	x *= math.Pi
+	return math.Sin(x) / x
+}
+
+// Contribution est la liste des poids des pixels sources qui forment un pixel de destination.
+type Contribution struct {
+	// Start est l'indice du premier pixel source, éventuellement hors de l'image.
+	Start int
+	// Weights sont les poids normalisés des pixels sources à partir de Start.
+	Weights []float64
+}
+
+// Contributions calcule, pour chaque pixel de destination, les poids normalisés des pixels sources.
+// En réduction, le filtre est élargi d'autant pour couvrir toute la surface du pixel de destination.
+func Contributions(srcSize, dstSize int, filter ResizeFilter) []Contribution {
+	kernel, support := filter.Kernel()
+	scale := float64(dstSize) / float64(srcSize)
+	stretch := 1.0
+	if scale < 1 {
+		stretch = 1 / scale
+	}
+	radius := support * stretch
+
+	result := make([]Contribution, dstSize)
+	for i := range result {
+		// Centre du pixel de destination dans le repère de la source
+		center := (float64(i)+0.5)/scale - 0.5
+		start := int(math.Ceil(center - radius))
+		end := int(math.Floor(center + radius))
+
+		weights := make([]float64, 0, end-start+1)
+		sum := 0.0
+		for j := start; j <= end; j++ {
+			w := kernel((float64(j) - center) / stretch)
+			weights = append(weights, w)
+			sum += w
+		}
+		if sum == 0 {
+			// Le filtre ne couvre aucun pixel : prendre le plus proche
+			start, weights, sum = int(math.Floor(center+0.5)), []float64{1}, 1
+		}
+		for j := range weights {
+			weights[j] /= sum
+		}
+		result[i] = Contribution{start, weights}
+	}
+	return result
+}
+
+// Resample applique les contributions à une ligne de size valeurs, en prolongeant les bords.
+func (c Contribution) Resample(at func(i int) float64, size int) float64 {
+	v := 0.0
+	for k, w := range c.Weights {
+		i := min(max(c.Start+k, 0), size-1)
+		v += w * at(i)
+	}
+	return v
+}
+
+// SRGBToLinear convertit une composante codée en sRGB, entre 0 et 1, en intensité lumineuse linéaire.
+func SRGBToLinear(v float64) float64 {
+	if v <= 0.04045 {
+		return v / 12.92
+	}
+	return math.Pow((v+0.055)/1.055, 2.4)
+}
+
+// LinearToSRGB est la conversion inverse de SRGBToLinear. v est d'abord ramené entre 0 et 1.
+func LinearToSRGB(v float64) float64 {
+	v = math.Max(0, math.Min(1, v))
+	if v <= 0.0031308 {
+		return v * 12.92
+	}
+	return 1.055*math.Pow(v, 1/2.4) - 0.055
+}
+
+// LinearTable renvoie la table de conversion des valeurs 0 à 255 d'une image de valeur maximale maxValue,
+// supposées codées en sRGB, vers la lumière linéaire entre 0 et 1.
+func LinearTable(maxValue int) []float64 {
+	if maxValue <= 0 {
+		maxValue = 255
+	}
+	table := make([]float64, 256)
+	for i := range table {
+		table[i] = SRGBToLinear(math.Min(float64(i)/float64(maxValue), 1))
+	}
+	return table
+}
+
+// Sample appelle visit pour chaque pixel source (i, j), éventuellement hors de l'image, qui contribue
+// au point (u, v) exprimé en indices de pixels, avec son poids non normalisé selon le filtre.
+func (f ResizeFilter) Sample(u, v float64, visit func(i, j int, w float64)) {
+	kernel, support := f.Kernel()
+	for j := int(math.Ceil(v - support)); j <= int(math.Floor(v+support)); j++ {
+		wy := kernel(float64(j) - v)
+		if wy == 0 {
+			continue
+		}
+		for i := int(math.Ceil(u - support)); i <= int(math.Floor(u+support)); i++ {
+			if w := wy * kernel(float64(i)-u); w != 0 {
+				visit(i, j, w)
+			}
+		}
+	}
+}
+
+// RotatedSize renvoie la taille du plus petit rectangle contenant une image width × height tournée.
+func RotatedSize(width, height int, cos, sin float64) (int, int) {
+	w := math.Abs(float64(width)*cos) + math.Abs(float64(height)*sin)
+	h := math.Abs(float64(width)*sin) + math.Abs(float64(height)*cos)
+	// La marge absorbe les erreurs d'arrondi des angles proches des multiples de 90°
+	return int(math.Ceil(w - 1e-6)), int(math.Ceil(h - 1e-6))
+}
@@ -0,0 +1,48 @@
+package raster
+
+import (
+	"math"
+	"testing"
+)
+
+var resizeFilters = []ResizeFilter{FilterBox, FilterBilinear, FilterBicubic, FilterMitchell, FilterLanczos}
+
+func TestContributionsNormalized(t *testing.T) {
+	for _, filter := range resizeFilters {
+		for _, sizes := range [][2]int{{10, 3}, {10, 27}, {7, 7}} {
+			for i, c := range Contributions(sizes[0], sizes[1], filter) {
+				sum := 0.0
+				for _, w := range c.Weights {
+					sum += w
+				}
+				if math.Abs(sum-1) > 1e-9 {
+					t.Errorf("Filter %d, %v: weights of pixel %d sum to %f", filter, sizes, i, sum)
+				}
+			}
+		}
+	}
+}
+
+func TestLinearRoundTrip(t *testing.T) {
+	table := LinearTable(255)
+	for i, v := range table {
+		if got := int(LinearToSRGB(v)*255 + 0.5); got != i {
+			t.Errorf("Value %d comes back as %d", i, got)
+		}
+	}
+}
+
+func TestSampleWeights(t *testing.T) {
+	for _, filter := range resizeFilters {
+		total := 0.0
+		filter.Sample(3, 4, func(i, j int, w float64) {
+			total += w
+		})
+		if total == 0 {
+			t.Errorf("Filter %d: no contribution at a pixel center", filter)
+		}
+	}
+	if w, h := RotatedSize(4, 2, 0, 1); w != 2 || h != 4 {
+		t.Errorf("RotatedSize at 90°: got %dx%d", w, h)
+	}
+}